@@ -15,33 +15,7 @@ func main() {
 	}
 
 	fmt.Println("Discovered parameters:")
-	values := make([]rule.Evaluation, 0)
 	for i, p := range exp.Params {
-		if p.Name == "book_pages" {
-			values = append(values, rule.Evaluation{
-				Param:  p,
-				Result: 150,
-			})
-		}
-		if p.Name == "language" {
-			values = append(values, rule.Evaluation{
-				Param:  p,
-				Result: "en",
-			})
-		}
-		if p.Name == "price" {
-			values = append(values, rule.Evaluation{
-				Param:  p,
-				Result: 100,
-			})
-		}
-		if p.Name == "in_stock" {
-			values = append(values, rule.Evaluation{
-				Param:  p,
-				Result: true,
-			})
-		}
-
 		if p.InputType == rule.FunctionCall {
 			fmt.Printf("\t%d)  Name=%q\n\t\tType: %s\n\t\tFunction Args: %v\n\t\tExpected Type: %v\n\n", i+1, p.Name, p.InputType.String(), p.FunctionArguments, p.Expression.String())
 		} else {
@@ -49,7 +23,14 @@ func main() {
 		}
 	}
 
-	res, err := exp.Evaluate(values)
+	book := map[string]any{
+		"book_pages": 150,
+		"language":   "en",
+		"price":      100,
+		"in_stock":   true,
+	}
+
+	res, err := exp.EvaluateMap(book)
 	if err != nil {
 		log.Fatalf("Evaluation error: %v", err)
 	}
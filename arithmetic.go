@@ -0,0 +1,441 @@
+package rule
+
+import (
+	"github.com/shopspring/decimal"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// valueExpr is a comparison value that can only be computed during evaluation, such as now() - 30d
+// or another attribute. ParseQuery folds arithmetic on constant values, so only expressions
+// involving now() or a paramRef remain.
+type valueExpr interface {
+	// eval computes the value. The second return value is false if it depends on a parameter
+	// that has no value.
+	eval(ec *evalContext) (any, bool, error)
+}
+
+// nowValue is the built-in now() function. It evaluates to the time given by Config.Now, or time.Now.
+type nowValue struct{}
+
+// eval returns the current time of the evaluation.
+func (nowValue) eval(ec *evalContext) (any, bool, error) {
+	return ec.now(), true, nil
+}
+
+// paramRef is the value of another attribute or function call, as in "shipped_at gt ordered_at".
+// The referenced Parameter is listed in Rule.Params like any other and resolved the same way.
+type paramRef struct {
+	param *Parameter
+}
+
+// eval resolves the referenced parameter. A nil value counts as missing.
+func (r paramRef) eval(ec *evalContext) (any, bool, error) {
+	val, ok, err := ec.value(r.param)
+	if err != nil || !ok || val == nil {
+		return nil, false, err
+	}
+	return val, true, nil
+}
+
+// lengthValue is the built-in len(attribute): the number of elements of a slice, array or map, or
+// the number of characters of a string. The attribute is listed in Rule.Params like any other.
+type lengthValue struct {
+	param *Parameter
+}
+
+// eval resolves the attribute and returns its length as an int64.
+func (l lengthValue) eval(ec *evalContext) (any, bool, error) {
+	val, ok, err := paramRef{param: l.param}.eval(ec)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	if s, ok := val.(string); ok {
+		return int64(utf8.RuneCountInString(s)), true, nil
+	}
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return int64(rv.Len()), true, nil
+	}
+	return nil, false, newErrorTypeMismatch("slice, array, map or string", typeName(val))
+}
+
+// arithmeticValue applies an arithmetic operator (+, -, *, / or %) to two values. Either side may be a valueExpr.
+type arithmeticValue struct {
+	op    string
+	left  any
+	right any
+}
+
+// eval computes both sides and applies op with computeArithmetic.
+func (a arithmeticValue) eval(ec *evalContext) (any, bool, error) {
+	left, ok, err := evalValue(a.left, ec)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	right, ok, err := evalValue(a.right, ec)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	result, err := computeArithmetic(left, a.op, right)
+	return result, err == nil, err
+}
+
+// evalValue computes v if it is a valueExpr and returns any other value unchanged.
+func evalValue(v any, ec *evalContext) (any, bool, error) {
+	if expr, ok := v.(valueExpr); ok {
+		return expr.eval(ec)
+	}
+	return v, true, nil
+}
+
+// computeArithmetic applies the arithmetic operator op (+, -, *, /, %) to two values.
+//
+// Times and durations support + and - (see computeTimeArithmetic). Numbers are promoted to a common
+// type first: decimal.Decimal if either side is one, then float64, then int64 or uint64 (see
+// toNumber). "/" divides integers exactly: 8 / 2 is the int64 4, but 7 / 2 is the float64 3.5, while
+// "%" keeps them integers.
+// Dividing by zero returns ErrorDivisionByZero, and a result outside the range of its type returns
+// ErrorArithmeticOverflow. Any other combination returns ErrorInvalidArithmetic.
+func computeArithmetic(left any, op string, right any) (any, error) {
+	switch left.(type) {
+	case time.Time, time.Duration:
+		return computeTimeArithmetic(left, op, right)
+	}
+	switch right.(type) {
+	case time.Time, time.Duration:
+		return computeTimeArithmetic(left, op, right)
+	}
+
+	l, lok := toNumber(left)
+	r, rok := toNumber(right)
+	if !lok || !rok {
+		return nil, newErrorInvalidArithmetic(typeName(left), op, typeName(right))
+	}
+
+	_, lDec := l.(decimal.Decimal)
+	_, rDec := r.(decimal.Decimal)
+	_, lFloat := l.(float64)
+	_, rFloat := r.(float64)
+	switch {
+	case lDec || rDec:
+		return computeDecimal(toDecimal(l), op, toDecimal(r))
+	case lFloat || rFloat:
+		return computeFloat(toFloat(l), op, toFloat(r))
+	}
+
+	li, lInt := l.(int64)
+	ri, rInt := r.(int64)
+	lu, _ := l.(uint64)
+	ru, _ := r.(uint64)
+	switch {
+	case lInt && rInt:
+		return computeInt(li, op, ri)
+	case !lInt && !rInt:
+		return computeUint(lu, op, ru)
+	case lInt && ru <= math.MaxInt64:
+		return computeInt(li, op, int64(ru))
+	case rInt && lu <= math.MaxInt64:
+		return computeInt(int64(lu), op, ri)
+	}
+	return nil, newErrorArithmeticOverflow(left, op, right)
+}
+
+// computeTimeArithmetic applies + or - to times and durations:
+//   - time.Time + time.Duration, time.Duration + time.Time and time.Time - time.Duration => time.Time
+//   - time.Time - time.Time => time.Duration
+//   - time.Duration + time.Duration and time.Duration - time.Duration => time.Duration
+func computeTimeArithmetic(left any, op string, right any) (any, error) {
+	switch l := left.(type) {
+	case time.Time:
+		switch r := right.(type) {
+		case time.Duration:
+			switch op {
+			case "+":
+				return l.Add(r), nil
+			case "-":
+				return l.Add(-r), nil
+			}
+		case time.Time:
+			if op == "-" {
+				return l.Sub(r), nil
+			}
+		}
+
+	case time.Duration:
+		switch r := right.(type) {
+		case time.Duration:
+			switch op {
+			case "+":
+				return computeInt64Duration(l, op, r, int64(l)+int64(r), (r > 0 && l+r < l) || (r < 0 && l+r > l))
+			case "-":
+				return computeInt64Duration(l, op, r, int64(l)-int64(r), (r > 0 && l-r > l) || (r < 0 && l-r < l))
+			}
+		case time.Time:
+			if op == "+" {
+				return r.Add(l), nil
+			}
+		}
+	}
+	return nil, newErrorInvalidArithmetic(typeName(left), op, typeName(right))
+}
+
+// computeInt64Duration returns result as a time.Duration, or ErrorArithmeticOverflow if it overflowed.
+func computeInt64Duration(left time.Duration, op string, right time.Duration, result int64, overflow bool) (any, error) {
+	if overflow {
+		return nil, newErrorArithmeticOverflow(left, op, right)
+	}
+	return time.Duration(result), nil
+}
+
+// computeInt applies op to two int64 values, checking for overflow.
+func computeInt(l int64, op string, r int64) (any, error) {
+	switch op {
+	case "+":
+		sum := l + r
+		if (r > 0 && sum < l) || (r < 0 && sum > l) {
+			return nil, newErrorArithmeticOverflow(l, op, r)
+		}
+		return sum, nil
+	case "-":
+		diff := l - r
+		if (r > 0 && diff > l) || (r < 0 && diff < l) {
+			return nil, newErrorArithmeticOverflow(l, op, r)
+		}
+		return diff, nil
+	case "*":
+		if l == 0 || r == 0 {
+			return int64(0), nil
+		}
+		product := l * r
+		if product/r != l || (l == -1 && r == math.MinInt64) || (r == -1 && l == math.MinInt64) {
+			return nil, newErrorArithmeticOverflow(l, op, r)
+		}
+		return product, nil
+	case "/":
+		if r == 0 {
+			return nil, newErrorDivisionByZero(l, op)
+		}
+		// An exact quotient stays an integer (MinInt64 / -1 does not fit in one)
+		if l%r == 0 && (l != math.MinInt64 || r != -1) {
+			return l / r, nil
+		}
+		return float64(l) / float64(r), nil
+	case "%":
+		if r == 0 {
+			return nil, newErrorDivisionByZero(l, op)
+		}
+		return l % r, nil
+	}
+	return nil, newErrorInvalidArithmetic("int64", op, "int64")
+}
+
+// computeUint applies op to two uint64 values, checking for overflow (including negative results).
+func computeUint(l uint64, op string, r uint64) (any, error) {
+	switch op {
+	case "+":
+		sum := l + r
+		if sum < l {
+			return nil, newErrorArithmeticOverflow(l, op, r)
+		}
+		return sum, nil
+	case "-":
+		if r > l {
+			return nil, newErrorArithmeticOverflow(l, op, r)
+		}
+		return l - r, nil
+	case "*":
+		product := l * r
+		if l != 0 && product/l != r {
+			return nil, newErrorArithmeticOverflow(l, op, r)
+		}
+		return product, nil
+	case "/":
+		if r == 0 {
+			return nil, newErrorDivisionByZero(l, op)
+		}
+		if l%r == 0 {
+			return l / r, nil
+		}
+		return float64(l) / float64(r), nil
+	case "%":
+		if r == 0 {
+			return nil, newErrorDivisionByZero(l, op)
+		}
+		return l % r, nil
+	}
+	return nil, newErrorInvalidArithmetic("uint64", op, "uint64")
+}
+
+// computeFloat applies op to two float64 values. A finite result that becomes infinite overflows.
+func computeFloat(l float64, op string, r float64) (any, error) {
+	var result float64
+	switch op {
+	case "+":
+		result = l + r
+	case "-":
+		result = l - r
+	case "*":
+		result = l * r
+	case "/", "%":
+		if r == 0 {
+			return nil, newErrorDivisionByZero(l, op)
+		}
+		if op == "/" {
+			result = l / r
+		} else {
+			result = math.Mod(l, r)
+		}
+	default:
+		return nil, newErrorInvalidArithmetic("float64", op, "float64")
+	}
+	if math.IsInf(result, 0) && !math.IsInf(l, 0) && !math.IsInf(r, 0) {
+		return nil, newErrorArithmeticOverflow(l, op, r)
+	}
+	return result, nil
+}
+
+// computeDecimal applies op to two decimal.Decimal values. Division uses decimal.DivisionPrecision.
+func computeDecimal(l decimal.Decimal, op string, r decimal.Decimal) (any, error) {
+	switch op {
+	case "+":
+		return l.Add(r), nil
+	case "-":
+		return l.Sub(r), nil
+	case "*":
+		return l.Mul(r), nil
+	case "/", "%":
+		if r.IsZero() {
+			return nil, newErrorDivisionByZero(l, op)
+		}
+		if op == "/" {
+			return l.Div(r), nil
+		}
+		return l.Mod(r), nil
+	}
+	return nil, newErrorInvalidArithmetic("decimal.Decimal", op, "decimal.Decimal")
+}
+
+// toNumber converts a numeric value to int64 (signed integers), uint64 (unsigned integers), float64
+// (floats) or decimal.Decimal. It reports false for any other value.
+func toNumber(v any) (any, bool) {
+	if d, ok := v.(decimal.Decimal); ok {
+		return d, true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return nil, false
+}
+
+// toDecimal converts a number returned by toNumber to a decimal.Decimal.
+func toDecimal(n any) decimal.Decimal {
+	switch v := n.(type) {
+	case int64:
+		return decimal.NewFromInt(v)
+	case uint64:
+		return decimal.NewFromUint64(v)
+	case float64:
+		return decimal.NewFromFloat(v)
+	}
+	return n.(decimal.Decimal)
+}
+
+// toFloat converts an int64, uint64 or float64 returned by toNumber to a float64.
+func toFloat(n any) float64 {
+	switch v := n.(type) {
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	}
+	return n.(float64)
+}
+
+// typeName returns the Go type of v for error messages, or "nil".
+func typeName(v any) string {
+	if v == nil {
+		return "nil"
+	}
+	return reflect.TypeOf(v).String()
+}
+
+// parseDuration parses a duration such as "72h", "1h30m" or "-500ms" like time.ParseDuration,
+// and additionally accepts the units d (24 hours) and w (7 days), e.g. "30d" or "1w2d".
+func parseDuration(s string) (time.Duration, error) {
+	if !strings.ContainsAny(s, "dw") {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, ErrorInvalidValue
+		}
+		return d, nil
+	}
+
+	rest := s
+	neg := false
+	if rest != "" && (rest[0] == '-' || rest[0] == '+') {
+		neg = rest[0] == '-'
+		rest = rest[1:]
+	}
+	if rest == "" {
+		return 0, ErrorInvalidValue
+	}
+
+	var total time.Duration
+	for rest != "" {
+		i := 0
+		for i < len(rest) && (rest[i] == '.' || '0' <= rest[i] && rest[i] <= '9') {
+			i++
+		}
+		j := i
+		for j < len(rest) && rest[j] != '.' && (rest[j] < '0' || rest[j] > '9') {
+			j++
+		}
+		num, unit := rest[:i], rest[i:j]
+		rest = rest[j:]
+
+		var d time.Duration
+		switch unit {
+		case "d", "w":
+			f, err := strconv.ParseFloat(num, 64)
+			if err != nil {
+				return 0, ErrorInvalidValue
+			}
+			day := 24 * time.Hour
+			if unit == "w" {
+				day *= 7
+			}
+			// float64(math.MaxInt64) rounds up to 2^63, which is already out of range
+			ns := f * float64(day)
+			if ns >= math.MaxInt64 {
+				return 0, ErrorInvalidValue
+			}
+			d = time.Duration(ns)
+		default:
+			var err error
+			d, err = time.ParseDuration(num + unit)
+			if err != nil {
+				return 0, ErrorInvalidValue
+			}
+		}
+		if total > math.MaxInt64-d {
+			return 0, ErrorInvalidValue
+		}
+		total += d
+	}
+	if neg {
+		total = -total
+	}
+	return total, nil
+}
@@ -0,0 +1,333 @@
+package rule
+
+import (
+	"errors"
+	"github.com/shopspring/decimal"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"72h", 72 * time.Hour, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"500ms", 500 * time.Millisecond, false},
+		{"-5m", -5 * time.Minute, false},
+		{"30d", 30 * 24 * time.Hour, false},
+		{"1.5d", 36 * time.Hour, false},
+		{"1w2d", 9 * 24 * time.Hour, false},
+		{"-1d12h", -36 * time.Hour, false},
+		{"", 0, true},
+		{"d", 0, true},
+		{"3x", 0, true},
+		{"2days", 0, true},
+		{"106751d", 106751 * 24 * time.Hour, false},
+		{"106752d", 0, true},
+		{"1000000000w", 0, true},
+		{"106751d24h", 0, true},
+		{"-15250w1d", -106751 * 24 * time.Hour, false},
+	}
+	for _, tt := range tests {
+		got, err := parseDuration(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDuration(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseDuration(%q) = %v; want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestComputeArithmetic(t *testing.T) {
+	ts := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		left  any
+		op    string
+		right any
+		want  any
+	}{
+		{ts, "-", 24 * time.Hour, ts.Add(-24 * time.Hour)},
+		{ts, "+", time.Hour, ts.Add(time.Hour)},
+		{time.Hour, "+", ts, ts.Add(time.Hour)},
+		{ts, "-", ts.Add(-time.Minute), time.Minute},
+		{time.Hour, "-", time.Minute, 59 * time.Minute},
+		{int64(2), "+", int64(3), int64(5)},
+		{3, "*", int32(4), int64(12)},
+		{int64(7), "/", int64(2), 3.5},
+		{int64(-8), "/", int64(2), int64(-4)},
+		{int64(math.MinInt64), "/", int64(-1), 9.223372036854775808e18},
+		{uint64(9), "/", uint64(3), uint64(3)},
+		{uint64(9), "/", uint64(2), 4.5},
+		{int64(7), "%", int64(-2), int64(1)},
+		{uint64(10), "-", uint64(4), uint64(6)},
+		{uint64(5), "+", int64(-2), int64(3)},
+		{1.5, "*", int64(2), 3.0},
+		{float32(0.5), "+", uint(1), 1.5},
+		{7.5, "%", 2.0, 1.5},
+	}
+	for _, tt := range tests {
+		got, err := computeArithmetic(tt.left, tt.op, tt.right)
+		if err != nil {
+			t.Errorf("computeArithmetic(%v %s %v) error: %v", tt.left, tt.op, tt.right, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("computeArithmetic(%v %s %v) = %v; want %v", tt.left, tt.op, tt.right, got, tt.want)
+		}
+	}
+
+	decimals := []struct {
+		left  any
+		op    string
+		right any
+		want  string
+	}{
+		{decimal.RequireFromString("0.1"), "+", 0.2, "0.3"},
+		{int64(10), "/", decimal.NewFromInt(4), "2.5"},
+		{decimal.RequireFromString("7.5"), "%", int64(2), "1.5"},
+	}
+	for _, tt := range decimals {
+		got, err := computeArithmetic(tt.left, tt.op, tt.right)
+		if err != nil {
+			t.Errorf("computeArithmetic(%v %s %v) error: %v", tt.left, tt.op, tt.right, err)
+			continue
+		}
+		if d, ok := got.(decimal.Decimal); !ok || !d.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("computeArithmetic(%v %s %v) = %v; want decimal %s", tt.left, tt.op, tt.right, got, tt.want)
+		}
+	}
+
+	failing := []struct {
+		left    any
+		op      string
+		right   any
+		wantErr error
+	}{
+		{int64(1), "/", int64(0), ErrorDivisionByZero},
+		{uint64(1), "%", uint64(0), ErrorDivisionByZero},
+		{1.5, "/", 0.0, ErrorDivisionByZero},
+		{decimal.NewFromInt(1), "%", decimal.Zero, ErrorDivisionByZero},
+		{int64(math.MaxInt64), "+", int64(1), ErrorArithmeticOverflow},
+		{int64(math.MinInt64), "-", int64(1), ErrorArithmeticOverflow},
+		{int64(math.MinInt64), "*", int64(-1), ErrorArithmeticOverflow},
+		{int64(1 << 32), "*", int64(1 << 32), ErrorArithmeticOverflow},
+		{uint64(1), "-", uint64(2), ErrorArithmeticOverflow},
+		{uint64(math.MaxUint64), "+", uint64(1), ErrorArithmeticOverflow},
+		{uint64(math.MaxUint64), "+", int64(-1), ErrorArithmeticOverflow},
+		{math.MaxFloat64, "*", 2.0, ErrorArithmeticOverflow},
+		{time.Duration(math.MaxInt64), "+", time.Nanosecond, ErrorArithmeticOverflow},
+		{"3", "*", int64(2), ErrorInvalidArithmetic},
+		{true, "+", int64(1), ErrorInvalidArithmetic},
+		{time.Hour, "*", int64(2), ErrorInvalidArithmetic},
+	}
+	for _, tt := range failing {
+		if _, err := computeArithmetic(tt.left, tt.op, tt.right); !errors.Is(err, tt.wantErr) {
+			t.Errorf("computeArithmetic(%v %s %v) error = %v; want %v", tt.left, tt.op, tt.right, err, tt.wantErr)
+		}
+	}
+
+	invalid := []struct {
+		left  any
+		op    string
+		right any
+	}{
+		{ts, "+", ts},
+		{time.Hour, "-", ts},
+		{ts, "+", int64(3)},
+		{"2025-01-01", "+", time.Hour},
+	}
+	for _, tt := range invalid {
+		if _, err := computeArithmetic(tt.left, tt.op, tt.right); !errors.Is(err, ErrorInvalidArithmetic) {
+			t.Errorf("computeArithmetic(%v %s %v) error = %v; want ErrorInvalidArithmetic", tt.left, tt.op, tt.right, err)
+		}
+	}
+}
+
+func TestEvaluateRelativeTime(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	clockCalls := 0
+	config := &Config{Now: func() time.Time {
+		clockCalls++
+		return now
+	}}
+	doc := map[string]any{
+		"last_login": "2025-02-20T08:00:00Z",
+		"expires_at": now.Add(48 * time.Hour),
+		"timeout":    "90s",
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`last_login gt now() - 30d`, true},
+		{`last_login gt now() - 14d`, false},
+		{`last_login gt now()-2w-1d`, false},
+		{`expires_at gt now() and expires_at le now() + 2d`, true},
+		{`expires_at lt now() + [dur]"47h"`, false},
+		{`last_login ge [date]"2025-02-01" + 19d`, true},
+		{`last_login lt [t]"2025-02-20T00:00:00Z" + 8h`, false},
+		{`timeout le 1m30s and timeout gt [dur]"1m"`, true},
+		{`timeout in [dur]["1m", "90s"]`, true},
+	}
+	for _, tt := range tests {
+		clockCalls = 0
+		r, err := ParseQuery(tt.query, config)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.EvaluateMap(doc)
+		if err != nil {
+			t.Fatalf("EvaluateMap(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateMap(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+		if clockCalls > 1 {
+			t.Errorf("EvaluateMap(%q) read the clock %d times; want at most once", tt.query, clockCalls)
+		}
+	}
+
+	// Without Config.Now, now() is the wall clock
+	r, err := ParseQuery(`ts gt now() - 1h`, nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if got, err := r.EvaluateMap(map[string]any{"ts": time.Now()}); err != nil || !got {
+		t.Errorf("EvaluateMap = %v, %v; want true, nil", got, err)
+	}
+
+	errorTests := []struct {
+		query   string
+		wantErr error
+	}{
+		{`ts gt now() + now()`, ErrorInvalidArithmetic},
+		{`ts gt 30d - now()`, ErrorInvalidArithmetic},
+		{`ts gt 5 + 3d`, ErrorInvalidArithmetic},
+		{`f(now()) eq 1`, ErrorInvalidFunctionCall},
+		{`ts gt [dur]"soon"`, ErrorInvalidValue},
+		{`ts gt [dur]"1000000000w"`, ErrorInvalidValue},
+		{`ts gt now() - 1000000000w`, ErrorInvalidValue},
+	}
+	for _, tt := range errorTests {
+		if _, err := ParseQuery(tt.query, nil); !errors.Is(err, tt.wantErr) {
+			t.Errorf("ParseQuery(%q) error = %v; want %v", tt.query, err, tt.wantErr)
+		}
+	}
+}
+
+func TestEvaluateArithmetic(t *testing.T) {
+	doc := map[string]any{
+		"price":     19.99,
+		"quantity":  60,
+		"score":     42,
+		"max_score": 50,
+		"a":         int64(2),
+		"b":         int64(3),
+		"c":         int64(4),
+		"balance":   decimal.RequireFromString("100.10"),
+		"fee":       decimal.RequireFromString("0.10"),
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`price * quantity gt 1000`, true},
+		{`price * quantity gt 1200`, false},
+		{`score / max_score ge 0.8`, true},
+		{`score / max_score ge 0.9`, false},
+		{`a + b * c eq 14`, true},
+		{`(a + b) * c eq 20`, true},
+		{`a * (b + c) eq a * b + a * c`, true},
+		{`c - b - a eq -1`, true},
+		{`price -1.5 gt 18`, true},
+		{`price - -1.5 gt 21`, true},
+		{`price * -1.5 lt -29.9`, true},
+		{`price gt -1.5e2 and b -1 eq 2`, true},
+		{`price in [-1.5, 19.99]`, true},
+		{`price nin [-1.5, 2.5]`, true},
+		{`b - c in [-1, 5]`, true},
+		{`c / a / a eq 1`, true},
+		{`c / a eq [i64]2 and b / a eq 1.5`, true},
+		{`quantity % 7 eq 4`, true},
+		{`not (quantity % 2 eq 0)`, false},
+		{`balance - fee eq [d]"100"`, true},
+		{`quantity eq 2 * 3 * 10`, true},
+		{`score gt max_score - 10 and score lt max_score`, true},
+		{`missing * 2 gt 0`, false},
+		{`not missing * 2 gt 0`, true},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.EvaluateMap(doc)
+		if err != nil {
+			t.Fatalf("EvaluateMap(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateMap(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+	}
+
+	// A computed left-hand side lists the attributes it refers to, in order
+	r, err := ParseQuery(`price * quantity gt 1000 and now() gt expires_at`, nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var names []string
+	for _, p := range r.Params {
+		names = append(names, p.Name)
+	}
+	if strings.Join(names, ",") != "price,quantity,expires_at" {
+		t.Errorf("Params = %v; want [price quantity expires_at]", names)
+	}
+
+	// Division by zero and overflow during evaluation are errors, not panics
+	evalErrors := []struct {
+		query   string
+		doc     map[string]any
+		wantErr error
+	}{
+		{`score / max_score ge 0.8`, map[string]any{"score": 1, "max_score": 0}, ErrorDivisionByZero},
+		{`score % max_score eq 0`, map[string]any{"score": 1, "max_score": 0}, ErrorDivisionByZero},
+		{`a * b gt 0`, map[string]any{"a": int64(math.MaxInt64), "b": 2}, ErrorArithmeticOverflow},
+		{`a - b gt 0`, map[string]any{"a": uint(1), "b": uint(2)}, ErrorArithmeticOverflow},
+		{`a + b gt 0`, map[string]any{"a": "1", "b": 2}, ErrorInvalidArithmetic},
+	}
+	for _, tt := range evalErrors {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		if _, err := r.EvaluateMap(tt.doc); !errors.Is(err, tt.wantErr) {
+			t.Errorf("EvaluateMap(%q, %v) error = %v; want %v", tt.query, tt.doc, err, tt.wantErr)
+		}
+	}
+
+	parseErrors := []struct {
+		query   string
+		wantErr error
+	}{
+		{`x gt 1 / 0`, ErrorDivisionByZero},
+		{`x gt 10 % (5 - 5)`, ErrorDivisionByZero},
+		{`x gt 9223372036854775807 + 1`, ErrorArithmeticOverflow},
+		{`x gt "a" * 2`, ErrorInvalidArithmetic},
+		{`1 + 2 gt x`, ErrorSyntaxError},
+		{`x gt (1 + 2`, ErrorSyntaxError},
+	}
+	for _, tt := range parseErrors {
+		if _, err := ParseQuery(tt.query, nil); !errors.Is(err, tt.wantErr) {
+			t.Errorf("ParseQuery(%q) error = %v; want %v", tt.query, err, tt.wantErr)
+		}
+	}
+}
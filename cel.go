@@ -0,0 +1,473 @@
+package rule
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"math"
+	"net/netip"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ToCEL translates the Rule into a Common Expression Language (CEL) expression, as used by
+// Kubernetes admission policies and Envoy, e.g.
+//
+//	expr, err := ruleSet.ToCEL()
+//	// age >= 18 && (name.startsWith("A") || has(address.city))
+//
+// The expression is plain text, so this package does not depend on CEL; compile it with an
+// environment that declares the top-level attributes as variables. Dotted names select fields
+// (address.city), and name parts that are not CEL identifiers become map keys (labels["app-name"]).
+//
+// "and", "or" and "not" become &&, || and !, and eq, ne, gt, ge, lt and le the CEL comparisons.
+// co, sw, ew and mr become contains, startsWith, endsWith and matches, in and nin become the in
+// operator (or contains for in with a string), and "pr" becomes has() (or != null for top-level
+// attributes). Attributes listed in Config.CaseInsensitive are compared with case-insensitive
+// matches. Value paths and any() become exists(), all() becomes all() and none() a negated
+// exists(); len becomes size(). Function calls become calls of CEL functions of the same name,
+// which the environment must declare.
+//
+// Values become literals of the matching CEL type: 1 is an int, [ui64]1 the uint 1u, 1.5 and
+// [f32]1.5 doubles, [d] decimals doubles (if they survive the conversion exactly), [t] and [date]
+// values timestamp() and [dur] values duration() calls. Note that CEL reports an error for a missing
+// field rather than treating the comparison as false, and does not mix int and double in arithmetic.
+// now(), wi, [ip], [cidr] and [semver] values, and case-insensitive comparisons with other attributes
+// fail with ErrorUntranslatable.
+func (g *Rule) ToCEL() (string, error) {
+	b := &celBuilder{used: make(map[string]bool)}
+	b.reserve(g.Params)
+	return b.condition(&g.exprTree, "")
+}
+
+// celReserved lists the words that cannot be used as CEL identifiers.
+var celReserved = map[string]bool{
+	"true": true, "false": true, "null": true, "in": true,
+	"as": true, "break": true, "const": true, "continue": true, "else": true, "for": true,
+	"function": true, "if": true, "import": true, "let": true, "loop": true, "package": true,
+	"namespace": true, "return": true, "var": true, "void": true, "while": true,
+}
+
+// celIdentifierPattern matches CEL identifiers.
+var celIdentifierPattern = regexp.MustCompile(`^[_a-zA-Z][_a-zA-Z0-9]*$`)
+
+// celComparisons maps the comparison operators to CEL.
+var celComparisons = map[string]string{
+	"eq": "==",
+	"ne": "!=",
+	"gt": ">",
+	"lt": "<",
+	"ge": ">=",
+	"le": "<=",
+}
+
+// celMethods maps the string operators to the CEL string functions.
+var celMethods = map[string]string{
+	"co": "contains",
+	"sw": "startsWith",
+	"ew": "endsWith",
+}
+
+// celBuilder holds the state of Rule.ToCEL while it translates the exprTree.
+//
+// Fields:
+//   - used: the top-level names of the attributes and the iteration variables in use, so that the
+//     variable of a comprehension such as tags.exists(e, ...) never hides an attribute
+type celBuilder struct {
+	used map[string]bool
+}
+
+// reserve marks the top-level names of params, including those of value path filters, as used.
+func (b *celBuilder) reserve(params []Parameter) {
+	for _, p := range params {
+		root, _, _ := strings.Cut(p.Name, ".")
+		b.used[root] = true
+		if p.Filter != nil {
+			b.reserve(p.Filter.Params)
+		}
+	}
+}
+
+// iterationVar returns an unused name for the variable of a comprehension: e, e2, e3 and so on.
+// The caller releases it with delete(b.used, name) once the comprehension is complete.
+func (b *celBuilder) iterationVar() string {
+	name := "e"
+	for n := 2; b.used[name]; n++ {
+		name = "e" + strconv.Itoa(n)
+	}
+	b.used[name] = true
+	return name
+}
+
+// condition translates an exprTree node. Inside a value path, scope is the iteration variable the
+// attribute names are relative to. An "and" inside an "or" (or the other way round) is always
+// parenthesized.
+func (b *celBuilder) condition(e *exprTree, scope string) (string, error) {
+	if e.op == "" {
+		if e.param == nil {
+			return "", ErrorNoExpression
+		}
+		cond, err := b.comparison(e.param, scope)
+		if err != nil {
+			return "", err
+		}
+		if e.not {
+			return "!(" + cond + ")", nil
+		}
+		return cond, nil
+	}
+
+	left, err := b.condition(e.left, scope)
+	if err != nil {
+		return "", err
+	}
+	right, err := b.condition(e.right, scope)
+	if err != nil {
+		return "", err
+	}
+	if e.left.op != "" && e.left.op != e.op && !e.left.not {
+		left = "(" + left + ")"
+	}
+	if e.right.op != "" && e.right.op != e.op && !e.right.not {
+		right = "(" + right + ")"
+	}
+	op := " && "
+	if e.op == "or" {
+		op = " || "
+	}
+	cond := left + op + right
+	if e.not {
+		return "!(" + cond + ")", nil
+	}
+	return cond, nil
+}
+
+// comparison translates the leaf comparison of p.
+func (b *celBuilder) comparison(p *Parameter, scope string) (string, error) {
+	if p.operator == "pr" {
+		return b.present(p, scope)
+	}
+
+	var left string
+	var err error
+	if p.leftExpr != nil {
+		left, err = b.operand(p.leftExpr, scope)
+	} else {
+		left, err = b.reference(p, scope)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if p.Filter != nil {
+		elem := b.iterationVar()
+		defer delete(b.used, elem)
+		cond, err := b.condition(p.Filter.exprTree, elem)
+		if err != nil {
+			return "", err
+		}
+		return left + ".exists(" + elem + ", " + cond + ")", nil
+	}
+
+	if p.quantifier == "" {
+		return b.compare(p, left, scope)
+	}
+	elem := b.iterationVar()
+	defer delete(b.used, elem)
+	cond, err := b.compare(p, elem, scope)
+	if err != nil {
+		return "", err
+	}
+	switch p.quantifier {
+	case "all":
+		return left + ".all(" + elem + ", " + cond + ")", nil
+	case "none":
+		return "!" + left + ".exists(" + elem + ", " + cond + ")", nil
+	}
+	return left + ".exists(" + elem + ", " + cond + ")", nil
+}
+
+// compare translates the comparison of left (the left-hand side of p) with p.compareValue.
+func (b *celBuilder) compare(p *Parameter, left string, scope string) (string, error) {
+	if p.compareValue == nil && (p.operator == "eq" || p.operator == "ne") {
+		return left + " " + celComparisons[p.operator] + " null", nil
+	}
+
+	fold := p.caseInsensitive && foldsCase(p.operator)
+	if fold {
+		return b.compareFolded(p, left)
+	}
+
+	switch p.operator {
+	case "eq", "ne", "gt", "lt", "ge", "le":
+		right, err := b.operand(p.compareValue, scope)
+		if err != nil {
+			return "", err
+		}
+		return left + " " + celComparisons[p.operator] + " " + right, nil
+
+	case "co", "sw", "ew":
+		if _, ok := p.compareValue.(valueExpr); !ok {
+			if _, ok := p.compareValue.(string); !ok {
+				return "", newErrorUntranslatable("CEL", fmt.Sprintf("%s needs a string value", p.operator))
+			}
+		}
+		right, err := b.operand(p.compareValue, scope)
+		if err != nil {
+			return "", err
+		}
+		return left + "." + celMethods[p.operator] + "(" + right + ")", nil
+
+	case "mr":
+		re, ok := p.compareValue.(*regexp.Regexp)
+		if !ok {
+			return "", newErrorUntranslatable("CEL", "mr needs a pattern")
+		}
+		return left + ".matches(" + strconv.Quote(re.String()) + ")", nil
+
+	case "in", "nin":
+		var cond string
+		switch x := p.compareValue.(type) {
+		case netip.Prefix, []netip.Prefix:
+			return "", newErrorUntranslatable("CEL", fmt.Sprintf("%s with networks has no CEL equivalent", p.operator))
+		case string:
+			// in with a string checks for a substring
+			cond = strconv.Quote(x) + ".contains(" + left + ")"
+		default:
+			right, err := b.operand(p.compareValue, scope)
+			if err != nil {
+				return "", err
+			}
+			cond = left + " in " + right
+		}
+		if p.operator == "nin" {
+			return "!(" + cond + ")", nil
+		}
+		return cond, nil
+	}
+	return "", newErrorUntranslatable("CEL", fmt.Sprintf("the operator %s has no CEL equivalent", p.operator))
+}
+
+// compareFolded translates a case-insensitive comparison into a match with the (?i) flag, which
+// like foldString uses Unicode simple case folding.
+func (b *celBuilder) compareFolded(p *Parameter, left string) (string, error) {
+	var pattern string
+	switch x := p.compareValue.(type) {
+	case string:
+		pattern = regexp.QuoteMeta(x)
+		switch p.operator {
+		case "eq", "ne":
+			pattern = "^" + pattern + "$"
+		case "sw":
+			pattern = "^" + pattern
+		case "ew":
+			pattern += "$"
+		case "in", "nin":
+			return "", newErrorUntranslatable("CEL", fmt.Sprintf("case-insensitive %s with a string has no CEL equivalent", p.operator))
+		}
+	case []string:
+		quoted := make([]string, len(x))
+		for i, s := range x {
+			quoted[i] = regexp.QuoteMeta(s)
+		}
+		pattern = "^(?:" + strings.Join(quoted, "|") + ")$"
+	default:
+		return "", newErrorUntranslatable("CEL", fmt.Sprintf("case-insensitive comparisons of %s need a constant string", p.Name))
+	}
+
+	cond := left + ".matches(" + strconv.Quote("(?i)"+pattern) + ")"
+	if p.operator == "ne" || p.operator == "nin" {
+		return "!" + cond, nil
+	}
+	return cond, nil
+}
+
+// present translates "pr": has() for a field, the in operator for a map key that is not an
+// identifier, and a comparison with null for a top-level attribute or function call.
+func (b *celBuilder) present(p *Parameter, scope string) (string, error) {
+	if p.InputType == FunctionCall || scope == "" && !strings.Contains(p.Name, ".") {
+		ref, err := b.reference(p, scope)
+		if err != nil {
+			return "", err
+		}
+		return ref + " != null", nil
+	}
+
+	parent := scope
+	name := p.Name
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		var err error
+		if parent, err = b.attribute(name[:i], scope); err != nil {
+			return "", err
+		}
+		name = name[i+1:]
+	}
+	if celIdentifier(name) {
+		return "has(" + parent + "." + name + ")", nil
+	}
+	return strconv.Quote(name) + " in " + parent, nil
+}
+
+// reference translates the attribute or function call of p.
+func (b *celBuilder) reference(p *Parameter, scope string) (string, error) {
+	if p.InputType != FunctionCall {
+		return b.attribute(p.Name, scope)
+	}
+	if !celIdentifier(p.Name) {
+		return "", newErrorUntranslatable("CEL", fmt.Sprintf("the function name %s is not a CEL identifier", p.Name))
+	}
+	args := make([]string, len(p.FunctionArguments))
+	for i, arg := range p.FunctionArguments {
+		var err error
+		if args[i], err = b.operand(arg.Value, scope); err != nil {
+			return "", err
+		}
+	}
+	return p.Name + "(" + strings.Join(args, ", ") + ")", nil
+}
+
+// attribute translates an attribute name, relative to the iteration variable scope if set. Each
+// dot-separated part selects a field, or a map key if it is not a CEL identifier.
+func (b *celBuilder) attribute(name string, scope string) (string, error) {
+	expr := scope
+	for _, part := range strings.Split(name, ".") {
+		switch {
+		case expr == "":
+			if !celIdentifier(part) {
+				return "", newErrorUntranslatable("CEL", fmt.Sprintf("the attribute %s is not a CEL identifier", part))
+			}
+			expr = part
+		case celIdentifier(part):
+			expr += "." + part
+		default:
+			expr += "[" + strconv.Quote(part) + "]"
+		}
+	}
+	return expr, nil
+}
+
+// operand translates a comparison value, a function argument or an operand of arithmetic.
+func (b *celBuilder) operand(v any, scope string) (string, error) {
+	switch x := v.(type) {
+	case paramRef:
+		return b.reference(x.param, scope)
+	case lengthValue:
+		ref, err := b.reference(x.param, scope)
+		if err != nil {
+			return "", err
+		}
+		return "size(" + ref + ")", nil
+	case arithmeticValue:
+		left, err := b.operand(x.left, scope)
+		if err != nil {
+			return "", err
+		}
+		right, err := b.operand(x.right, scope)
+		if err != nil {
+			return "", err
+		}
+		// Like computeArithmetic, "/" divides exactly
+		if x.op == "/" {
+			return "(double(" + left + ") / double(" + right + "))", nil
+		}
+		return "(" + left + " " + x.op + " " + right + ")", nil
+	case nowValue:
+		return "", newErrorUntranslatable("CEL", "now() has no CEL equivalent")
+	}
+	return celLiteral(v)
+}
+
+// celLiteral returns the CEL literal of a constant, of the CEL type matching its Go type.
+func celLiteral(v any) (string, error) {
+	switch x := v.(type) {
+	case nil:
+		return "null", nil
+	case bool:
+		return strconv.FormatBool(x), nil
+	case string:
+		return strconv.Quote(x), nil
+	case int:
+		return strconv.FormatInt(int64(x), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(x), 10), nil
+	case int64:
+		return strconv.FormatInt(x, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(x), 10) + "u", nil
+	case uint32:
+		return strconv.FormatUint(uint64(x), 10) + "u", nil
+	case uint64:
+		return strconv.FormatUint(x, 10) + "u", nil
+	case float32:
+		return celDouble(float64(x), 32), nil
+	case float64:
+		return celDouble(x, 64), nil
+	case decimal.Decimal:
+		// CEL has no decimal type
+		f := x.InexactFloat64()
+		if !decimal.NewFromFloat(f).Equal(x) {
+			return "", newErrorUntranslatable("CEL", fmt.Sprintf("the decimal %s cannot be represented exactly as a CEL double", x))
+		}
+		return celDouble(f, 64), nil
+	case time.Time:
+		return "timestamp(" + strconv.Quote(x.Format(time.RFC3339Nano)) + ")", nil
+	case time.Duration:
+		return "duration(" + strconv.Quote(celSeconds(x)) + ")", nil
+	case netip.Addr, netip.Prefix, Semver:
+		return "", newErrorUntranslatable("CEL", fmt.Sprintf("%s values have no CEL equivalent", typeName(v)))
+	}
+
+	list := reflect.ValueOf(v)
+	if list.Kind() != reflect.Slice {
+		return "", newErrorUntranslatable("CEL", fmt.Sprintf("%s values have no CEL equivalent", typeName(v)))
+	}
+	elems := make([]string, list.Len())
+	for i := range elems {
+		var err error
+		if elems[i], err = celLiteral(list.Index(i).Interface()); err != nil {
+			return "", err
+		}
+	}
+	return "[" + strings.Join(elems, ", ") + "]", nil
+}
+
+// celDouble returns the CEL literal of a double with the shortest representation for bitSize,
+// which always has a decimal point or an exponent, so that e.g. 2.0 is not read as the int 2.
+func celDouble(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return `double("NaN")`
+	case math.IsInf(f, 1):
+		return `double("Infinity")`
+	case math.IsInf(f, -1):
+		return `double("-Infinity")`
+	}
+	s := strconv.FormatFloat(f, 'g', -1, bitSize)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// celSeconds formats d as seconds with up to nine decimals, e.g. "90s" or "-1.5s", the form
+// duration() accepts in every CEL implementation.
+func celSeconds(d time.Duration) string {
+	sign := ""
+	u := uint64(d)
+	if d < 0 {
+		sign = "-"
+		u = -u
+	}
+	secs, nanos := u/uint64(time.Second), u%uint64(time.Second)
+	if nanos == 0 {
+		return fmt.Sprintf("%s%ds", sign, secs)
+	}
+	return sign + strings.TrimRight(fmt.Sprintf("%d.%09d", secs, nanos), "0") + "s"
+}
+
+// celIdentifier reports whether name is a CEL identifier, and not a reserved word.
+func celIdentifier(name string) bool {
+	return celIdentifierPattern.MatchString(name) && !celReserved[name]
+}
@@ -0,0 +1,164 @@
+package rule
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToCEL(t *testing.T) {
+	functions := NewFunctionRegistry()
+	if err := functions.Register("get_score", func(args ...any) (any, error) { return 0, nil }, ArgTypeString); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	config := &Config{Functions: functions, CaseInsensitive: []string{"email", "lang", "emails.value"}}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`age gt 30`, `age > 30`},
+		{`age ge 18 and age lt 65`, `age >= 18 && age < 65`},
+		{`a eq 1 or b eq 2 and c eq 3`, `a == 1 || (b == 2 && c == 3)`},
+		{`(a eq 1 or b eq 2) and c eq 3`, `(a == 1 || b == 2) && c == 3`},
+		{`not age gt 30`, `!(age > 30)`},
+		{`not (a eq 1 or b eq 2)`, `!(a == 1 || b == 2)`},
+		{`status ne "active"`, `status != "active"`},
+		{`address.city eq "London"`, `address.city == "London"`},
+		{`labels.app-name eq "web"`, `labels["app-name"] == "web"`},
+		{`spec.if eq 1`, `spec["if"] == 1`},
+
+		// Presence and null
+		{`email pr`, `email != null`},
+		{`address.city pr`, `has(address.city)`},
+		{`not labels.app-name pr`, `!("app-name" in labels)`},
+		{`deleted_at eq null`, `deleted_at == null`},
+		{`deleted_at ne null`, `deleted_at != null`},
+
+		// String functions
+		{`name co "a\"b"`, `name.contains("a\"b")`},
+		{`name sw "Dr."`, `name.startsWith("Dr.")`},
+		{`name ew "son"`, `name.endsWith("son")`},
+		{`sku mr "^[A-Z]{3}-\\d+$"`, `sku.matches("^[A-Z]{3}-\\d+$")`},
+		{`email eq "Ada@Example.com"`, `email.matches("(?i)^Ada@Example\\.com$")`},
+		{`email ne "a@x.org"`, `!email.matches("(?i)^a@x\\.org$")`},
+		{`email ew "@example.com"`, `email.matches("(?i)@example\\.com$")`},
+		{`email co "ada"`, `email.matches("(?i)ada")`},
+
+		// Lists
+		{`lang in ["en", "fr"]`, `lang.matches("(?i)^(?:en|fr)$")`},
+		{`role in ["admin", "dev"]`, `role in ["admin", "dev"]`},
+		{`id nin [1, 2]`, `!(id in [1, 2])`},
+		{`code in "ABCDEF"`, `"ABCDEF".contains(code)`},
+		{`role in allowed_roles`, `role in allowed_roles`},
+
+		// Typed literals
+		{`price lt 9.5`, `price < 9.5`},
+		{`price lt [f64]2`, `price < 2.0`},
+		{`price lt [f32]1.1`, `price < 1.1`},
+		{`price le [d]"9.99"`, `price <= 9.99`},
+		{`count eq [i32]5`, `count == 5`},
+		{`count eq [ui64]5`, `count == 5u`},
+		{`count eq [ui]5`, `count == 5u`},
+		{`ids in [ui64][1, 2]`, `ids in [1u, 2u]`},
+		{`active eq true`, `active == true`},
+		{`created_at ge [date]"2025-01-01"`, `created_at >= timestamp("2025-01-01T00:00:00Z")`},
+		{`created_at lt [t]"2025-01-01T10:30:00.5+02:00"`, `created_at < timestamp("2025-01-01T10:30:00.5+02:00")`},
+		{`timeout gt [dur]"1h30m"`, `timeout > duration("5400s")`},
+		{`timeout lt [dur]"-1.5ms"`, `timeout < duration("-0.0015s")`},
+
+		// Attributes, arithmetic, len and functions
+		{`shipped_at gt ordered_at`, `shipped_at > ordered_at`},
+		{`shipped_at le ordered_at + 2d`, `shipped_at <= (ordered_at + duration("172800s"))`},
+		{`price * quantity gt 1000`, `(price * quantity) > 1000`},
+		{`score / max_score ge 0.8`, `(double(score) / double(max_score)) >= 0.8`},
+		{`len(items) gt 3`, `size(items) > 3`},
+		{`get_score("math") gt 90`, `get_score("math") > 90`},
+		{`get_score("math") pr`, `get_score("math") != null`},
+
+		// Multi-valued attributes
+		{`any(tags) eq "vip"`, `tags.exists(e, e == "vip")`},
+		{`all(scores) ge 50`, `scores.all(e, e >= 50)`},
+		{`none(roles) eq "banned"`, `!roles.exists(e, e == "banned")`},
+		{`any(e) eq 1`, `e.exists(e2, e2 == 1)`},
+		{`emails[type eq "work" and value ew "@example.com"]`, `emails.exists(e, e.type == "work" && e.value.matches("(?i)@example\\.com$"))`},
+		{`emails[primary pr] and any(tags) eq "vip"`, `emails.exists(e, has(e.primary)) && tags.exists(e, e == "vip")`},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, config)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.ToCEL()
+		if err != nil {
+			t.Errorf("ToCEL(%q) error: %v", tt.query, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ToCEL(%q) = %s; want %s", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestCELLiteral(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{nil, "null"},
+		{"line\nbreak", `"line\nbreak"`},
+		{int64(-7), "-7"},
+		{uint32(7), "7u"},
+		{1e21, "1e+21"},
+		{float32(0.1), "0.1"},
+		{time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), `timestamp("2025-01-01T00:00:00Z")`},
+		{90 * time.Second, `duration("90s")`},
+		{[]string{"a", "b"}, `["a", "b"]`},
+		{[]float64{1, 2.5}, `[1.0, 2.5]`},
+	}
+	for _, tt := range tests {
+		got, err := celLiteral(tt.value)
+		if err != nil {
+			t.Errorf("celLiteral(%#v) error: %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("celLiteral(%#v) = %s; want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestToCELUntranslatable(t *testing.T) {
+	config := &Config{CaseInsensitive: []string{"email"}}
+	tests := []struct {
+		query  string
+		reason string
+	}{
+		{`last_login gt now() - 30d`, "now()"},
+		{`client_ip wi "10.0.0.0/8"`, "operator wi"},
+		{`client_ip in [cidr]["10.0.0.0/8"]`, "networks"},
+		{`client_ip eq [ip]"10.0.0.1"`, "netip.Addr"},
+		{`version gt [semver]"1.2.3"`, "Semver"},
+		{`price eq [d]"0.1234567890123456789"`, "exactly"},
+		{`email eq other_email`, "constant string"},
+		{`email in "abc"`, "case-insensitive in"},
+		{`name co 5`, "string value"},
+		{`x-y eq 1`, "not a CEL identifier"},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, config)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		_, err = r.ToCEL()
+		if !errors.Is(err, ErrorUntranslatable) || !strings.Contains(err.Error(), tt.reason) {
+			t.Errorf("ToCEL(%q) error = %v; want ErrorUntranslatable mentioning %q", tt.query, err, tt.reason)
+		}
+	}
+
+	var empty Rule
+	if _, err := empty.ToCEL(); !errors.Is(err, ErrorNoExpression) {
+		t.Errorf("ToCEL of an empty Rule: error = %v; want ErrorNoExpression", err)
+	}
+}
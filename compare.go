@@ -1,12 +1,19 @@
 package rule
 
 import (
+	"errors"
 	"fmt"
 	"github.com/shopspring/decimal"
 	"golang.org/x/exp/constraints"
+	"math"
+	"net"
+	"net/netip"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
 // compareDecimal compares two decimal.Decimal values using the provided operator string.
@@ -38,25 +45,74 @@ func compareDecimal(leftVal decimal.Decimal, operator string, rightVal decimal.D
 // if not, a type mismatch error is returned.
 //
 // Non-decimal numeric comparisons are delegated to compareOrdered() for standard ordering (>, <, etc.).
-// String operations (co, sw, ew, in, pr) go to compareStringOps().
+// Membership operations (in, nin) go to compareMembership(), which checks the elements one by one.
+// Regular expression matches (mr) go to compareMatch().
+// If either side is a time.Time, the comparison goes to compareTime(); for a time.Duration it goes to compareDuration().
+// IP addresses (netip.Addr) go to compareIP(), and "within network" checks (wi) go to compareWithin().
+// Semantic versions (Semver) go to compareSemver().
+// String operations (co, sw, ew, pr) go to compareStringOps().
 // Decimal comparisons go to compareDecimal().
 func compareOperator(leftVal any, operator string, rightVal any, strictTypeCheck bool) (bool, error) {
+	// Membership applies the strict type check to each element of a list
+	switch operator {
+	case "in", "nin":
+		return compareMembership(leftVal, operator, rightVal, strictTypeCheck)
+	case "mr":
+		return compareMatch(leftVal, rightVal, strictTypeCheck)
+	case "wi":
+		return compareWithin(leftVal, rightVal, strictTypeCheck)
+	}
+
+	// null only equals null, whatever the type of the other side
+	if rightVal == nil && (operator == "eq" || operator == "ne") {
+		return (leftVal == nil) == (operator == "eq"), nil
+	}
+
+	// Times accept RFC 3339 strings and Unix timestamps on the other side, even with strictTypeCheck
+	_, leftTime := leftVal.(time.Time)
+	_, rightTime := rightVal.(time.Time)
+	if leftTime || rightTime {
+		return compareTime(leftVal, operator, rightVal, strictTypeCheck)
+	}
+	_, leftIP := leftVal.(netip.Addr)
+	_, rightIP := rightVal.(netip.Addr)
+	if leftIP || rightIP {
+		return compareIP(leftVal, operator, rightVal, strictTypeCheck)
+	}
+	_, leftSemver := leftVal.(Semver)
+	_, rightSemver := rightVal.(Semver)
+	if leftSemver || rightSemver {
+		return compareSemver(leftVal, operator, rightVal, strictTypeCheck)
+	}
+	_, leftDuration := leftVal.(time.Duration)
+	_, rightDuration := rightVal.(time.Duration)
+	if leftDuration || rightDuration {
+		return compareDuration(leftVal, operator, rightVal, strictTypeCheck)
+	}
+
 	// Enforce strict type check if requested
 	if strictTypeCheck {
 		if reflect.TypeOf(leftVal) != reflect.TypeOf(rightVal) {
-			return false, newErrorTypeMismatch(reflect.TypeOf(rightVal).String(), reflect.TypeOf(leftVal).String())
+			return false, newErrorTypeMismatch(typeName(rightVal), typeName(leftVal))
 		}
 	}
 
 	// Handle special string-based operators
 	switch operator {
-	case "co", "sw", "ew", "in", "pr":
+	case "co", "sw", "ew", "pr":
 		return compareStringOps(leftVal, operator, rightVal)
 	}
 
 	// Handle decimals
 	if ld, ok := leftVal.(decimal.Decimal); ok {
-		rd := rightVal.(decimal.Decimal)
+		rd, ok := rightVal.(decimal.Decimal)
+		if !ok {
+			var err error
+			rd, err = decimal.NewFromString(fmt.Sprintf("%v", rightVal))
+			if err != nil {
+				return false, ErrorInvalidValue
+			}
+		}
 		return compareDecimal(ld, operator, rd)
 	}
 
@@ -160,8 +216,47 @@ func compareOperator(leftVal any, operator string, rightVal any, strictTypeCheck
 		}
 
 	default:
-		return false, newErrorTypeMismatch(reflect.TypeOf(rightVal).String(), reflect.TypeOf(leftVal).String())
+		return false, newErrorTypeMismatch(typeName(rightVal), typeName(leftVal))
+	}
+}
+
+// compareQuantified compares each element of the multi-valued leftVal with rightVal using
+// compareOperator, and combines the results according to quantifier: "any" (at least one element
+// matches), "all" (every element matches) or "none" (no element matches). leftVal may be a slice
+// or an array; any other value counts as a single element. Pointer elements are dereferenced, a nil
+// element never matches, and an empty list satisfies "all" and "none". With fold set, elements are
+// compared after foldCase.
+func compareQuantified(quantifier string, leftVal any, operator string, rightVal any, strictTypeCheck bool, fold bool) (bool, error) {
+	elems := []any{leftVal}
+	if rv := reflect.ValueOf(leftVal); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		elems = make([]any, rv.Len())
+		for i := range elems {
+			elems[i], _ = leafValue(rv.Index(i))
+		}
 	}
+
+	for _, elem := range elems {
+		matched := false
+		if elem != nil {
+			if fold {
+				elem = foldCase(elem)
+			}
+			var err error
+			matched, err = compareOperator(elem, operator, rightVal, strictTypeCheck)
+			if err != nil {
+				return false, err
+			}
+		}
+		switch {
+		case quantifier == "any" && matched:
+			return true, nil
+		case quantifier == "all" && !matched:
+			return false, nil
+		case quantifier == "none" && matched:
+			return false, nil
+		}
+	}
+	return quantifier != "any", nil
 }
 
 // compareOrdered is a generic helper for numeric or string ordering comparisons
@@ -187,12 +282,249 @@ func compareOrdered[T constraints.Ordered](left T, operator string, right T) (bo
 	}
 }
 
-// compareStringOps handles string-based comparisons: co, sw, ew, in, pr.
+// compareTime compares two points in time using the provided operator (eq, ne, gt, lt, ge, le).
+// Each side may be a time.Time or a value accepted by toTime. If one cannot be converted, the error is
+// ErrorInvalidValue, or a type mismatch when strictTypeCheck is set.
+func compareTime(leftVal any, operator string, rightVal any, strictTypeCheck bool) (bool, error) {
+	switch operator {
+	case "eq", "ne", "gt", "lt", "ge", "le":
+	default:
+		return false, newErrorInvalidOperator(operator, "time.Time")
+	}
+
+	l, lok := toTime(leftVal)
+	r, rok := toTime(rightVal)
+	if !lok || !rok {
+		if strictTypeCheck {
+			return false, newErrorTypeMismatch(typeName(rightVal), typeName(leftVal))
+		}
+		return false, ErrorInvalidValue
+	}
+
+	switch operator {
+	case "eq":
+		return l.Equal(r), nil
+	case "ne":
+		return !l.Equal(r), nil
+	case "gt":
+		return l.After(r), nil
+	case "lt":
+		return l.Before(r), nil
+	case "ge":
+		return !l.Before(r), nil
+	default: // "le"
+		return !l.After(r), nil
+	}
+}
+
+// toTime converts v to a time.Time. It accepts a time.Time, an RFC 3339 string, a date string
+// such as "2025-01-01" (midnight UTC), and Unix timestamps in seconds as integers or floats.
+func toTime(v any) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case string:
+		if parsed, err := time.Parse(time.RFC3339Nano, t); err == nil {
+			return parsed, true
+		}
+		if parsed, err := time.Parse(time.DateOnly, t); err == nil {
+			return parsed, true
+		}
+		return time.Time{}, false
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return time.Unix(rv.Int(), 0).UTC(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return time.Unix(int64(rv.Uint()), 0).UTC(), true
+	case reflect.Float32, reflect.Float64:
+		sec, frac := math.Modf(rv.Float())
+		return time.Unix(int64(sec), int64(frac*1e9)).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// compareDuration compares two durations using the provided operator (eq, ne, gt, lt, ge, le).
+// Each side may be a time.Duration or a duration string such as "72h" or "30d".
+func compareDuration(leftVal any, operator string, rightVal any, strictTypeCheck bool) (bool, error) {
+	l, lok := toDuration(leftVal)
+	r, rok := toDuration(rightVal)
+	if !lok || !rok {
+		if strictTypeCheck {
+			return false, newErrorTypeMismatch(typeName(rightVal), typeName(leftVal))
+		}
+		return false, ErrorInvalidValue
+	}
+	return compareOrdered(l, operator, r)
+}
+
+// toDuration converts a time.Duration or a duration string (see parseDuration) to a time.Duration.
+func toDuration(v any) (time.Duration, bool) {
+	switch d := v.(type) {
+	case time.Duration:
+		return d, true
+	case string:
+		parsed, err := parseDuration(d)
+		return parsed, err == nil
+	}
+	return 0, false
+}
+
+// compareIP compares two IP addresses using the provided operator (eq, ne, gt, lt, ge, le).
+// Each side may be a netip.Addr or a value accepted by toAddr. IPv4-mapped IPv6 addresses equal
+// their IPv4 form, and IPv4 addresses order before IPv6 addresses.
+func compareIP(leftVal any, operator string, rightVal any, strictTypeCheck bool) (bool, error) {
+	switch operator {
+	case "eq", "ne", "gt", "lt", "ge", "le":
+	default:
+		return false, newErrorInvalidOperator(operator, "netip.Addr")
+	}
+
+	l, lok := toAddr(leftVal)
+	r, rok := toAddr(rightVal)
+	if !lok || !rok {
+		if strictTypeCheck {
+			return false, newErrorTypeMismatch(typeName(rightVal), typeName(leftVal))
+		}
+		return false, ErrorInvalidValue
+	}
+	return compareOrdered(l.Compare(r), operator, 0)
+}
+
+// compareSemver compares two semantic versions by precedence using the provided operator
+// (eq, ne, gt, lt, ge, le). Each side may be a Semver or a version string such as "2.10.0".
+func compareSemver(leftVal any, operator string, rightVal any, strictTypeCheck bool) (bool, error) {
+	switch operator {
+	case "eq", "ne", "gt", "lt", "ge", "le":
+	default:
+		return false, newErrorInvalidOperator(operator, "Semver")
+	}
+
+	l, lok := toSemver(leftVal)
+	r, rok := toSemver(rightVal)
+	if !lok || !rok {
+		if strictTypeCheck {
+			return false, newErrorTypeMismatch(typeName(rightVal), typeName(leftVal))
+		}
+		return false, ErrorInvalidValue
+	}
+	return compareOrdered(l.Compare(r), operator, 0)
+}
+
+// compareWithin handles the "within network" operator wi: leftVal is an IP address (see toAddr)
+// and rightVal a netip.Prefix or a []netip.Prefix, as prepared by ParseQuery. The result is true
+// if one of the prefixes contains the address. A value that is not an address never matches,
+// unless strictTypeCheck reports it as a type mismatch.
+func compareWithin(leftVal any, rightVal any, strictTypeCheck bool) (bool, error) {
+	addr, ok := toAddr(leftVal)
+	if !ok {
+		if strictTypeCheck {
+			return false, newErrorTypeMismatch("netip.Addr", typeName(leftVal))
+		}
+		return false, nil
+	}
+
+	switch networks := rightVal.(type) {
+	case netip.Prefix:
+		return networks.Contains(addr), nil
+	case []netip.Prefix:
+		for _, prefix := range networks {
+			if prefix.Contains(addr) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, newErrorInvalidOperator("wi", typeName(rightVal))
+}
+
+// toAddr converts a netip.Addr, a net.IP or an address string such as "10.0.0.1" or "2001:db8::1"
+// to a netip.Addr. IPv4-mapped IPv6 addresses are converted to IPv4.
+func toAddr(v any) (netip.Addr, bool) {
+	var addr netip.Addr
+	var ok bool
+	switch a := v.(type) {
+	case netip.Addr:
+		addr, ok = a, a.IsValid()
+	case net.IP:
+		addr, ok = netip.AddrFromSlice(a)
+	case string:
+		parsed, err := netip.ParseAddr(a)
+		addr, ok = parsed, err == nil
+	}
+	return addr.Unmap(), ok
+}
+
+// compareMembership handles the membership operators in and nin.
+//
+// If rightVal is a list (e.g. the []int64 parsed from [1, 2, 3]), leftVal is a member when it equals
+// one of the elements according to compareOperator, including its numeric coercion and strict type
+// check. Elements that cannot be converted to the type of leftVal are never equal to it.
+// For networks ([cidr] values), membership means the address is within one of them (see compareWithin).
+// Any other scalar rightVal falls back to the substring check of compareStringOps.
+func compareMembership(leftVal any, operator string, rightVal any, strictTypeCheck bool) (bool, error) {
+	// Networks ([cidr] values) contain addresses
+	switch rightVal.(type) {
+	case netip.Prefix, []netip.Prefix:
+		in, err := compareWithin(leftVal, rightVal, strictTypeCheck)
+		if operator == "nin" {
+			return !in, err
+		}
+		return in, err
+	}
+
+	list := reflect.ValueOf(rightVal)
+	if list.Kind() != reflect.Slice {
+		return compareStringOps(leftVal, operator, rightVal)
+	}
+
+	found := false
+	for i := 0; i < list.Len() && !found; i++ {
+		eq, err := compareOperator(leftVal, "eq", list.Index(i).Interface(), strictTypeCheck)
+		if errors.Is(err, ErrorInvalidValue) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		found = eq
+	}
+	if operator == "nin" {
+		return !found, nil
+	}
+	return found, nil
+}
+
+// compareMatch handles the regular expression match operator mr. rightVal is normally the
+// *regexp.Regexp compiled by ParseQuery; any other value is compiled as a pattern first.
+// leftVal is converted to a string, unless strictTypeCheck requires it to be one.
+func compareMatch(leftVal any, rightVal any, strictTypeCheck bool) (bool, error) {
+	re, ok := rightVal.(*regexp.Regexp)
+	if !ok {
+		var err error
+		re, err = regexp.Compile(fmt.Sprint(rightVal))
+		if err != nil {
+			return false, fmt.Errorf("%w: %w", ErrorInvalidPattern, err)
+		}
+	}
+	if l, ok := leftVal.(string); ok {
+		return re.MatchString(l), nil
+	}
+	if strictTypeCheck {
+		return false, newErrorTypeMismatch("string", typeName(leftVal))
+	}
+	return re.MatchString(fmt.Sprint(leftVal)), nil
+}
+
+// compareStringOps handles string-based comparisons: co, sw, ew, in, nin, pr.
 //
 // - co => "contains": strings.Contains(leftVal, rightVal)
 // - sw => "starts with": strings.HasPrefix(leftVal, rightVal)
 // - ew => "ends with": strings.HasSuffix(leftVal, rightVal)
-// - in => substring check (implemented as strings.Contains(rightVal, leftVal))
+// - in => substring check for a scalar rightVal (implemented as strings.Contains(rightVal, leftVal))
+// - nin => negated substring check for a scalar rightVal
 // - pr => "present" => leftVal != nil
 func compareStringOps(leftVal any, operator string, rightVal any) (bool, error) {
 	// "pr" => param is present (not nil). We only check for nil in leftVal.
@@ -226,7 +558,50 @@ func compareStringOps(leftVal any, operator string, rightVal any) (bool, error)
 	case "in":
 		// "in" => check if l is in r
 		return strings.Contains(r, l), nil
+	case "nin":
+		// "nin" => check if l is not in r
+		return !strings.Contains(r, l), nil
 	}
 
 	return false, ErrorUnknownStringOperator
 }
+
+// foldsCase reports whether operator takes part in case-insensitive comparisons (see Config.CaseInsensitive).
+func foldsCase(operator string) bool {
+	switch operator {
+	case "eq", "ne", "co", "sw", "ew", "in", "nin":
+		return true
+	}
+	return false
+}
+
+// foldCase maps a string, or each element of a []string, to its case-folded form (see foldString).
+// Any other value is returned unchanged.
+func foldCase(v any) any {
+	switch s := v.(type) {
+	case string:
+		return foldString(s)
+	case []string:
+		folded := make([]string, len(s))
+		for i := range s {
+			folded[i] = foldString(s[i])
+		}
+		return folded
+	}
+	return v
+}
+
+// foldString replaces every rune of s with the smallest rune of its Unicode simple case folding
+// orbit (e.g. 'K', 'k' and the Kelvin sign all become 'K'), so two strings are equal after
+// folding exactly when strings.EqualFold reports them equal.
+func foldString(s string) string {
+	return strings.Map(func(r rune) rune {
+		folded := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < folded {
+				folded = f
+			}
+		}
+		return folded
+	}, s)
+}
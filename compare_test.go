@@ -2,8 +2,12 @@ package rule
 
 import (
 	"github.com/shopspring/decimal"
+	"net"
+	"net/netip"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestCompareDecimal(t *testing.T) {
@@ -150,6 +154,295 @@ func TestCompareStringOps(t *testing.T) {
 	}
 }
 
+func TestCompareTime(t *testing.T) {
+	ts := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		left          any
+		operator      string
+		right         any
+		strict        bool
+		want          bool
+		wantErrSubstr string
+	}{
+		{ts, "eq", time.Date(2025, 1, 1, 14, 0, 0, 0, time.FixedZone("CEST", 2*3600)), true, true, ""},
+		{ts, "gt", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), true, true, ""},
+		{ts, "le", ts, true, true, ""},
+		{ts, "lt", ts, true, false, ""},
+
+		// RFC 3339 strings, dates and Unix timestamps are coerced, even with strict checks
+		{"2025-01-01T12:00:00Z", "eq", ts, true, true, ""},
+		{"2025-01-01T11:59:59.999Z", "lt", ts, true, true, ""},
+		{"2025-01-02", "gt", ts, false, true, ""},
+		{int64(1735732800), "eq", ts, true, true, ""},
+		{1735732800, "ge", ts, false, true, ""},
+		{uint32(1735732799), "lt", ts, false, true, ""},
+		{1735732800.5, "gt", ts, false, true, ""},
+		{ts, "ne", "2025-01-01T12:00:00Z", false, false, ""},
+		{ts, "in", []time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), ts}, true, true, ""},
+		{"2025-01-01T12:00:00Z", "nin", []time.Time{ts}, true, false, ""},
+
+		// Values that are not times
+		{"yesterday", "lt", ts, false, false, "invalid value"},
+		{true, "eq", ts, true, false, "mismatch"},
+		{ts, "co", "2025", false, false, "invalid operator"},
+	}
+
+	for i, tc := range tests {
+		got, err := compareOperator(tc.left, tc.operator, tc.right, tc.strict)
+		if tc.wantErrSubstr != "" {
+			if err == nil || !contains(err.Error(), tc.wantErrSubstr) {
+				t.Errorf("[%d] compareOperator(%v, %s, %v, strict=%t) => error=%v, want substring %q",
+					i, tc.left, tc.operator, tc.right, tc.strict, err, tc.wantErrSubstr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%d] unexpected error: %v", i, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("[%d] compareOperator(%v, %s, %v, strict=%t) got=%v, want=%v", i, tc.left, tc.operator, tc.right, tc.strict, got, tc.want)
+		}
+	}
+}
+
+func TestCompareNetworks(t *testing.T) {
+	addr := netip.MustParseAddr("10.1.2.3")
+	private := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("fd00::/8")}
+	tests := []struct {
+		left          any
+		operator      string
+		right         any
+		strict        bool
+		want          bool
+		wantErrSubstr string
+	}{
+		{"10.1.2.3", "eq", addr, true, true, ""},
+		{"::ffff:10.1.2.3", "eq", addr, true, true, ""},
+		{net.ParseIP("10.1.2.3"), "eq", addr, true, true, ""},
+		{"10.1.2.10", "gt", addr, false, true, ""},
+		{"9.255.255.255", "lt", addr, false, true, ""},
+		{"::1", "gt", addr, false, true, ""}, // IPv4 orders before IPv6
+		{addr, "ne", "10.1.2.3", false, false, ""},
+		{"10.1.2.3", "in", []netip.Addr{netip.MustParseAddr("10.1.2.4"), addr}, true, true, ""},
+
+		{"10.200.0.1", "wi", private[0], false, true, ""},
+		{"11.0.0.1", "wi", private[0], false, false, ""},
+		{"fd12::1", "wi", private, false, true, ""},
+		{"::ffff:10.0.0.1", "wi", private, false, true, ""},
+		{"2001:db8::1", "wi", private, false, false, ""},
+		{"10.0.0.1", "in", private[0], false, true, ""},
+		{"10.0.0.1", "nin", private, false, false, ""},
+		{"8.8.8.8", "nin", private, false, true, ""},
+
+		{"not an ip", "wi", private, false, false, ""},
+		{int64(3), "wi", private, true, false, "mismatch"},
+		{"not an ip", "eq", addr, false, false, "invalid value"},
+		{addr, "co", "10.1", false, false, "invalid operator"},
+	}
+
+	for i, tc := range tests {
+		got, err := compareOperator(tc.left, tc.operator, tc.right, tc.strict)
+		if tc.wantErrSubstr != "" {
+			if err == nil || !contains(err.Error(), tc.wantErrSubstr) {
+				t.Errorf("[%d] compareOperator(%v, %s, %v, strict=%t) => error=%v, want substring %q",
+					i, tc.left, tc.operator, tc.right, tc.strict, err, tc.wantErrSubstr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%d] unexpected error: %v", i, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("[%d] compareOperator(%v, %s, %v, strict=%t) got=%v, want=%v", i, tc.left, tc.operator, tc.right, tc.strict, got, tc.want)
+		}
+	}
+}
+
+func TestCompareMembership(t *testing.T) {
+	tests := []struct {
+		left          any
+		operator      string
+		right         any
+		strict        bool
+		want          bool
+		wantErrSubstr string
+	}{
+		// Exact membership, not substrings
+		{int64(1), "in", []int64{10, 20}, false, false, ""},
+		{int64(20), "in", []int64{10, 20}, false, true, ""},
+		{"a", "in", []string{"abc", "b"}, false, false, ""},
+		{"b", "in", []string{"abc", "b"}, false, true, ""},
+		{"b", "nin", []string{"abc", "b"}, false, false, ""},
+		{int64(1), "nin", []int64{10, 20}, false, true, ""},
+
+		// Numeric coercion follows compareOperator
+		{17, "in", []int64{17, 18}, false, true, ""},
+		{uint32(18), "in", []int64{17, 18}, false, true, ""},
+		{2.5, "in", []float64{1.5, 2.5}, false, true, ""},
+		{float32(1.5), "in", []float64{1.5}, false, true, ""},
+		{decimal.RequireFromString("1.5"), "in", []float64{1.5}, false, true, ""},
+		{decimal.RequireFromString("2"), "in", []int64{1, 2}, false, true, ""},
+		{"10", "in", []int64{10}, false, true, ""},
+
+		// Elements that cannot be converted are not members
+		{int64(3), "in", []string{"x", "3"}, false, true, ""},
+		{int64(3), "in", []float64{3.5}, false, false, ""},
+		{int64(3), "nin", []string{"x"}, false, true, ""},
+
+		// Strict checks apply to the elements
+		{decimal.RequireFromString("1.5"), "in", []decimal.Decimal{decimal.RequireFromString("1.50")}, true, true, ""},
+		{1.5, "in", []decimal.Decimal{decimal.RequireFromString("1.5")}, true, false, "mismatch"},
+
+		// Scalars keep the substring check
+		{"abc", "nin", "zzzabczzz", false, false, ""},
+		{"abc", "nin", "abd", false, true, ""},
+	}
+
+	for i, tc := range tests {
+		got, err := compareOperator(tc.left, tc.operator, tc.right, tc.strict)
+		if tc.wantErrSubstr != "" {
+			if err == nil || !contains(err.Error(), tc.wantErrSubstr) {
+				t.Errorf("[%d] compareOperator(%v, %s, %v, strict=%t) => error=%v, want substring %q",
+					i, tc.left, tc.operator, tc.right, tc.strict, err, tc.wantErrSubstr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%d] unexpected error: %v", i, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("[%d] compareOperator(%v, %s, %v, strict=%t) got=%v, want=%v", i, tc.left, tc.operator, tc.right, tc.strict, got, tc.want)
+		}
+	}
+}
+
+func TestCompareQuantified(t *testing.T) {
+	one, two := 1, 2
+	tests := []struct {
+		quantifier    string
+		left          any
+		operator      string
+		right         any
+		fold          bool
+		want          bool
+		wantErrSubstr string
+	}{
+		{"any", []string{"new", "vip"}, "eq", "vip", false, true, ""},
+		{"any", []string{"new"}, "eq", "vip", false, false, ""},
+		{"all", []int{50, 72, 90}, "ge", int64(50), false, true, ""},
+		{"all", []int{50, 49}, "ge", int64(50), false, false, ""},
+		{"none", []string{"user", "admin"}, "eq", "banned", false, true, ""},
+		{"none", []string{"user", "banned"}, "eq", "banned", false, false, ""},
+
+		// Empty lists
+		{"any", []string{}, "eq", "x", false, false, ""},
+		{"all", []string{}, "eq", "x", false, true, ""},
+		{"none", []string{}, "eq", "x", false, true, ""},
+
+		// Elements of any type use compareOperator coercion
+		{"all", []any{float64(72), int64(50), "60"}, "ge", int64(50), false, true, ""},
+		{"any", [3]float32{1.5, 2.5, 3.5}, "gt", 3.0, false, true, ""},
+		{"any", []*int{nil, &one, &two}, "eq", int64(2), false, true, ""},
+		{"all", []*int{nil, &two}, "eq", int64(2), false, false, ""},
+		{"any", []decimal.Decimal{decimal.RequireFromString("9.99")}, "lt", decimal.RequireFromString("10"), false, true, ""},
+		{"any", []string{"alpha", "beta"}, "in", []string{"beta", "gamma"}, false, true, ""},
+		{"any", []string{"Vip"}, "eq", foldString("vip"), true, true, ""},
+		{"any", []any{"vIP"}, "eq", foldString("vip"), true, true, ""},
+		{"any", []string{"Vip"}, "eq", foldString("vip"), false, false, ""},
+
+		// A single value counts as one element
+		{"any", "vip", "eq", "vip", false, true, ""},
+
+		// Errors from compareOperator are returned
+		{"any", []any{true}, "gt", true, false, false, "invalid operator"},
+	}
+
+	for i, tc := range tests {
+		got, err := compareQuantified(tc.quantifier, tc.left, tc.operator, tc.right, false, tc.fold)
+		if tc.wantErrSubstr != "" {
+			if err == nil || !contains(err.Error(), tc.wantErrSubstr) {
+				t.Errorf("[%d] compareQuantified(%s, %v, %s, %v) => error=%v, want substring %q",
+					i, tc.quantifier, tc.left, tc.operator, tc.right, err, tc.wantErrSubstr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%d] unexpected error: %v", i, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("[%d] compareQuantified(%s, %v, %s, %v) got=%v, want=%v", i, tc.quantifier, tc.left, tc.operator, tc.right, got, tc.want)
+		}
+	}
+}
+
+func TestCompareMatch(t *testing.T) {
+	re := regexp.MustCompile(`^SKU-\d+$`)
+	tests := []struct {
+		left          any
+		right         any
+		strict        bool
+		want          bool
+		wantErrSubstr string
+	}{
+		{"SKU-123", re, false, true, ""},
+		{"sku-123", re, false, false, ""},
+		{"SKU-12a", re, false, false, ""},
+		{int64(42), regexp.MustCompile(`^4\d$`), false, true, ""},
+		{int64(42), re, true, false, "mismatch"},
+		{"abc", "b+", false, true, ""},
+		{"abc", "[", false, false, "invalid pattern"},
+	}
+
+	for i, tc := range tests {
+		got, err := compareOperator(tc.left, "mr", tc.right, tc.strict)
+		if tc.wantErrSubstr != "" {
+			if err == nil || !contains(err.Error(), tc.wantErrSubstr) {
+				t.Errorf("[%d] compareOperator(%v, mr, %v) => error=%v, want substring %q", i, tc.left, tc.right, err, tc.wantErrSubstr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%d] unexpected error: %v", i, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("[%d] compareOperator(%v, mr, %v) got=%v, want=%v", i, tc.left, tc.right, got, tc.want)
+		}
+	}
+}
+
+func TestFoldCase(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Hello", "hELLO", true},
+		{"STRASSE", "strasse", true},
+		{"Ärger", "äRGER", true},
+		{"\u212a", "k", true}, // Kelvin sign
+		{"ΣΊΣΥΦΟΣ", "σίσυφος", true},
+		{"ς", "Σ", true}, // final sigma
+		{"hello", "world", false},
+	}
+	for _, tt := range tests {
+		got := foldString(tt.a) == foldString(tt.b)
+		if got != tt.want || got != strings.EqualFold(tt.a, tt.b) {
+			t.Errorf("foldString(%q) == foldString(%q) => %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	if got := foldCase([]string{"A", "b"}).([]string); got[0] != foldString("a") || got[1] != foldString("B") {
+		t.Errorf("foldCase([]string) => %v", got)
+	}
+	if got := foldCase(int64(3)); got != int64(3) {
+		t.Errorf("foldCase(int64) => %v, want it unchanged", got)
+	}
+}
+
 // Helper for substring check:
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
@@ -0,0 +1,337 @@
+package rule
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ElasticsearchOptions controls Rule.ToElasticsearch.
+//
+// Fields:
+//   - Field: maps an attribute name (Parameter.Name, e.g. "address.city") to the field of the index;
+//     return an error to reject an attribute. keyword is true for the term, terms, prefix and wildcard
+//     queries of eq, ne, in, nin, sw, ew and co, which need a keyword field rather than an analyzed
+//     text field, e.g. "name.keyword" for the text field "name". If nil, the name is used as is
+type ElasticsearchOptions struct {
+	Field func(name string, keyword bool) (string, error)
+}
+
+// ToElasticsearch translates the Rule into an Elasticsearch (or OpenSearch) query, a map that
+// encodes to the query DSL with encoding/json, e.g.
+//
+//	query, err := ruleSet.ToElasticsearch(nil)
+//	body, err := json.Marshal(map[string]any{"query": query})
+//
+// "and" becomes a bool query with must, "or" one with should, and a negation one with must_not, which
+// like "not" in Evaluate also matches documents without the field. eq, in, gt, lt, ge and le become
+// term, terms and range queries, sw a prefix query, and co and ew wildcard queries, with
+// case_insensitive set for attributes listed in Config.CaseInsensitive. ne and nin also require the
+// field to exist, as in Evaluate. "pr" becomes an exists query, "ne null" too, and "eq null" a
+// must_not with exists, which like Evaluate matches a missing or null field. wi becomes a term or terms query with the CIDR prefixes, for
+// ip fields. Value paths become nested queries, any() is the query itself (Elasticsearch matches any
+// value of an array) and none() a must_not with exists.
+//
+// Values are passed through as is, so decimals and times encode as their JSON representation, and now()
+// and arithmetic on constants are computed (with Config.Now). mr, all(), len, attributes or arithmetic on
+// the right-hand side, computed left-hand sides, in / nin with a string, [semver] and [dur] values and
+// function calls fail with ErrorUntranslatable.
+func (g *Rule) ToElasticsearch(opts *ElasticsearchOptions) (map[string]any, error) {
+	b := &elasticBuilder{
+		field: func(name string, _ bool) (string, error) { return name, nil },
+		ec:    &evalContext{ctx: context.Background(), clock: g.clock},
+	}
+	if opts != nil && opts.Field != nil {
+		b.field = opts.Field
+	}
+	if b.ec.clock == nil {
+		b.ec.clock = time.Now
+	}
+	return b.query(&g.exprTree, "")
+}
+
+// elasticRanges maps the ordering operators to the parameters of a range query.
+var elasticRanges = map[string]string{
+	"gt": "gt",
+	"lt": "lt",
+	"ge": "gte",
+	"le": "lte",
+}
+
+// elasticBuilder holds the settings of Rule.ToElasticsearch while it translates the exprTree.
+//
+// Fields:
+//   - field: from ElasticsearchOptions, or the identity
+//   - ec: computes now() and arithmetic on constants, reading the clock at most once
+type elasticBuilder struct {
+	field func(name string, keyword bool) (string, error)
+	ec    *evalContext
+}
+
+// query translates an exprTree node. Inside a value path, prefix is the path of the nested
+// documents (e.g. "emails."), which is prepended to the attribute names of the filter.
+func (b *elasticBuilder) query(e *exprTree, prefix string) (map[string]any, error) {
+	var q map[string]any
+	if e.op == "" {
+		if e.param == nil {
+			return nil, ErrorNoExpression
+		}
+		var err error
+		if q, err = b.comparison(e.param, prefix); err != nil {
+			return nil, err
+		}
+	} else {
+		occur := "must"
+		if e.op == "or" {
+			occur = "should"
+		}
+		var clauses []any
+		for _, child := range []*exprTree{e.left, e.right} {
+			sub, err := b.query(child, prefix)
+			if err != nil {
+				return nil, err
+			}
+			// a and (b and c) is a single bool query with a, b and c
+			if nested, ok := boolClauses(sub, occur); ok {
+				clauses = append(clauses, nested...)
+				continue
+			}
+			clauses = append(clauses, sub)
+		}
+		q = boolQuery(occur, clauses...)
+	}
+
+	if e.not {
+		return boolQuery("must_not", q), nil
+	}
+	return q, nil
+}
+
+// boolQuery returns a bool query with clauses in occur (must, should or must_not). A should query
+// requires at least one of its clauses to match.
+func boolQuery(occur string, clauses ...any) map[string]any {
+	body := map[string]any{occur: clauses}
+	if occur == "should" {
+		body["minimum_should_match"] = 1
+	}
+	return map[string]any{"bool": body}
+}
+
+// boolClauses returns the clauses of q if q is a bool query with nothing but clauses in occur.
+func boolClauses(q map[string]any, occur string) ([]any, bool) {
+	body, ok := q["bool"].(map[string]any)
+	if !ok || len(q) != 1 {
+		return nil, false
+	}
+	keys := 1
+	if occur == "should" {
+		keys = 2 // minimum_should_match
+	}
+	clauses, ok := body[occur].([]any)
+	if !ok || len(body) != keys {
+		return nil, false
+	}
+	return clauses, true
+}
+
+// comparison translates the leaf comparison of p.
+func (b *elasticBuilder) comparison(p *Parameter, prefix string) (map[string]any, error) {
+	if p.leftExpr != nil {
+		return nil, newErrorUntranslatable("Elasticsearch", fmt.Sprintf("the computed value %s has no Elasticsearch equivalent", p.Name))
+	}
+	if p.InputType == FunctionCall {
+		return nil, newErrorUntranslatable("Elasticsearch", fmt.Sprintf("the function %s has no Elasticsearch equivalent", p.Name))
+	}
+
+	if p.Filter != nil {
+		path, err := b.field(prefix+p.Name, false)
+		if err != nil {
+			return nil, err
+		}
+		sub, err := b.query(p.Filter.exprTree, prefix+p.Name+".")
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"nested": map[string]any{"path": path, "query": sub}}, nil
+	}
+
+	q, err := b.leaf(p, prefix+p.Name)
+	if err != nil {
+		return nil, err
+	}
+	switch p.quantifier {
+	case "", "any":
+		return q, nil
+	case "none":
+		return b.existsButNot(prefix+p.Name, q)
+	}
+	return nil, newErrorUntranslatable("Elasticsearch", fmt.Sprintf("the quantifier %s(%s) has no Elasticsearch equivalent", p.quantifier, p.Name))
+}
+
+// leaf translates the operator and value of p for the attribute name.
+func (b *elasticBuilder) leaf(p *Parameter, name string) (map[string]any, error) {
+	fold := p.caseInsensitive && foldsCase(p.operator)
+
+	switch p.operator {
+	case "pr":
+		return b.exists(name)
+	case "mr":
+		return nil, newErrorUntranslatable("Elasticsearch", "Go regular expressions (mr) have no Elasticsearch equivalent")
+	case "in", "nin", "wi":
+		return b.terms(p, name, fold)
+	}
+
+	value, err := b.constant(p.compareValue)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil && (p.operator == "eq" || p.operator == "ne") {
+		exists, err := b.exists(name)
+		if err != nil {
+			return nil, err
+		}
+		if p.operator == "eq" {
+			return boolQuery("must_not", exists), nil
+		}
+		return exists, nil
+	}
+
+	if r, ok := elasticRanges[p.operator]; ok {
+		field, err := b.field(name, false)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"range": map[string]any{field: map[string]any{r: value}}}, nil
+	}
+
+	field, err := b.field(name, true)
+	if err != nil {
+		return nil, err
+	}
+	var q map[string]any
+	switch p.operator {
+	case "eq", "ne":
+		q = map[string]any{"term": map[string]any{field: elasticValue(value, fold)}}
+	case "sw", "co", "ew":
+		s, ok := value.(string)
+		if !ok {
+			return nil, newErrorUntranslatable("Elasticsearch", fmt.Sprintf("%s needs a string value", p.operator))
+		}
+		switch p.operator {
+		case "sw":
+			q = map[string]any{"prefix": map[string]any{field: elasticValue(s, fold)}}
+		case "co":
+			q = map[string]any{"wildcard": map[string]any{field: elasticValue("*"+escapeWildcard(s)+"*", fold)}}
+		case "ew":
+			q = map[string]any{"wildcard": map[string]any{field: elasticValue("*"+escapeWildcard(s), fold)}}
+		}
+	default:
+		return nil, newErrorUntranslatable("Elasticsearch", fmt.Sprintf("the operator %s has no Elasticsearch equivalent", p.operator))
+	}
+
+	if p.operator == "ne" {
+		return b.existsButNot(name, q)
+	}
+	return q, nil
+}
+
+// terms translates in, nin and wi (whose values are CIDR prefixes) into a terms query, or a term query
+// for a single prefix. Case-insensitive lists become a should of case-insensitive term queries.
+func (b *elasticBuilder) terms(p *Parameter, name string, fold bool) (map[string]any, error) {
+	field, err := b.field(name, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var q map[string]any
+	list := reflect.ValueOf(p.compareValue)
+	switch {
+	case p.operator == "wi" && list.Kind() != reflect.Slice:
+		q = map[string]any{"term": map[string]any{field: map[string]any{"value": p.compareValue.(netip.Prefix).String()}}}
+	case list.Kind() != reflect.Slice:
+		return nil, newErrorUntranslatable("Elasticsearch", fmt.Sprintf("%s with a string checks for a substring", p.operator))
+	default:
+		values := make([]any, list.Len())
+		for i := range values {
+			value, err := b.constant(list.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		if fold {
+			clauses := make([]any, len(values))
+			for i, value := range values {
+				clauses[i] = map[string]any{"term": map[string]any{field: elasticValue(value, true)}}
+			}
+			q = boolQuery("should", clauses...)
+		} else {
+			q = map[string]any{"terms": map[string]any{field: values}}
+		}
+	}
+
+	if p.operator == "nin" {
+		return b.existsButNot(name, q)
+	}
+	return q, nil
+}
+
+// exists returns an exists query for the attribute name.
+func (b *elasticBuilder) exists(name string) (map[string]any, error) {
+	field, err := b.field(name, false)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"exists": map[string]any{"field": field}}, nil
+}
+
+// existsButNot returns a query for documents that have the attribute name but do not match q.
+func (b *elasticBuilder) existsButNot(name string, q map[string]any) (map[string]any, error) {
+	exists, err := b.exists(name)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"bool": map[string]any{"must": []any{exists}, "must_not": []any{q}}}, nil
+}
+
+// constant converts a comparison value for the query. now() and arithmetic on constants are
+// computed; attributes and other computed values cannot be expressed in a query.
+func (b *elasticBuilder) constant(v any) (any, error) {
+	if hasRefs(v) {
+		return nil, newErrorUntranslatable("Elasticsearch", "comparisons with other attributes have no Elasticsearch equivalent")
+	}
+	if expr, ok := v.(valueExpr); ok {
+		var err error
+		if v, _, err = expr.eval(b.ec); err != nil {
+			return nil, err
+		}
+	}
+	switch x := v.(type) {
+	case netip.Addr:
+		return x.String(), nil
+	case netip.Prefix:
+		return x.String(), nil
+	case Semver, time.Duration:
+		return nil, newErrorUntranslatable("Elasticsearch", fmt.Sprintf("%s values have no Elasticsearch equivalent", typeName(v)))
+	}
+	return v, nil
+}
+
+// elasticValue returns the parameters of a term, prefix or wildcard query for value.
+func elasticValue(value any, fold bool) map[string]any {
+	if fold {
+		return map[string]any{"value": value, "case_insensitive": true}
+	}
+	return map[string]any{"value": value}
+}
+
+// escapeWildcard escapes the wildcards * and ? in s, and the escape character \ itself.
+func escapeWildcard(s string) string {
+	return wildcardEscaper.Replace(s)
+}
+
+// wildcardEscaper prefixes the wildcards of a wildcard query and the escape character with a backslash.
+var wildcardEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`)
@@ -0,0 +1,157 @@
+package rule
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/shopspring/decimal"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToElasticsearch(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	config := &Config{Now: func() time.Time { return now }, CaseInsensitive: []string{"email", "lang"}}
+
+	type M = map[string]any
+	type A = []any
+	exists := func(field string) M { return M{"exists": M{"field": field}} }
+	term := func(field string, value any) M { return M{"term": M{field: M{"value": value}}} }
+	tests := []struct {
+		query string
+		want  M
+	}{
+		{`age gt 30`, M{"range": M{"age": M{"gt": int64(30)}}}},
+		{`status eq "active"`, term("status", "active")},
+		{`age ge 18 and age lt 65 and active eq true`, M{"bool": M{"must": A{
+			M{"range": M{"age": M{"gte": int64(18)}}},
+			M{"range": M{"age": M{"lt": int64(65)}}},
+			term("active", true),
+		}}}},
+		{`a eq 1 or b eq 2 or c eq 3`, M{"bool": M{"should": A{term("a", int64(1)), term("b", int64(2)), term("c", int64(3))}, "minimum_should_match": 1}}},
+		{`a eq 1 or b eq 2 and c eq 3`, M{"bool": M{"should": A{
+			term("a", int64(1)),
+			M{"bool": M{"must": A{term("b", int64(2)), term("c", int64(3))}}},
+		}, "minimum_should_match": 1}}},
+		{`not (a eq 1 and b eq 2)`, M{"bool": M{"must_not": A{M{"bool": M{"must": A{term("a", int64(1)), term("b", int64(2))}}}}}}},
+		{`status ne "active"`, M{"bool": M{"must": A{exists("status")}, "must_not": A{term("status", "active")}}}},
+
+		// Presence and null
+		{`email pr`, exists("email")},
+		{`not email pr`, M{"bool": M{"must_not": A{exists("email")}}}},
+		{`deleted_at eq null`, M{"bool": M{"must_not": A{exists("deleted_at")}}}},
+		{`deleted_at ne null`, exists("deleted_at")},
+		{`not deleted_at eq null`, M{"bool": M{"must_not": A{M{"bool": M{"must_not": A{exists("deleted_at")}}}}}}},
+
+		// Strings
+		{`name sw "Ad"`, M{"prefix": M{"name": M{"value": "Ad"}}}},
+		{`name co "a*b?"`, M{"wildcard": M{"name": M{"value": `*a\*b\?*`}}}},
+		{`name ew "son"`, M{"wildcard": M{"name": M{"value": "*son"}}}},
+		{`email ew "@Example.com"`, M{"wildcard": M{"email": M{"value": "*@Example.com", "case_insensitive": true}}}},
+		{`email eq "Ada@Example.com"`, M{"term": M{"email": M{"value": "Ada@Example.com", "case_insensitive": true}}}},
+
+		// Lists and networks
+		{`role in ["admin", "dev"]`, M{"terms": M{"role": A{"admin", "dev"}}}},
+		{`id nin [1, 2]`, M{"bool": M{"must": A{exists("id")}, "must_not": A{M{"terms": M{"id": A{int64(1), int64(2)}}}}}}},
+		{`lang in ["en", "fr"]`, M{"bool": M{"should": A{
+			M{"term": M{"lang": M{"value": "en", "case_insensitive": true}}},
+			M{"term": M{"lang": M{"value": "fr", "case_insensitive": true}}},
+		}, "minimum_should_match": 1}}},
+		{`client_ip wi "10.0.0.0/8"`, term("client_ip", "10.0.0.0/8")},
+		{`client_ip wi ["10.0.0.0/8", "fd00::/8"]`, M{"terms": M{"client_ip": A{"10.0.0.0/8", "fd00::/8"}}}},
+
+		// Typed values pass through
+		{`price le [d]"9.99"`, M{"range": M{"price": M{"lte": decimal.RequireFromString("9.99")}}}},
+		{`created_at ge [date]"2025-01-01"`, M{"range": M{"created_at": M{"gte": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}}}},
+		{`last_login gt now() - 30d`, M{"range": M{"last_login": M{"gt": now.Add(-30 * 24 * time.Hour)}}}},
+
+		// Arrays
+		{`emails[type eq "work" and value ew "@example.com"]`, M{"nested": M{"path": "emails", "query": M{"bool": M{"must": A{
+			term("emails.type", "work"),
+			M{"wildcard": M{"emails.value": M{"value": "*@example.com"}}},
+		}}}}}},
+		{`any(tags) eq "vip"`, term("tags", "vip")},
+		{`none(roles) eq "banned"`, M{"bool": M{"must": A{exists("roles")}, "must_not": A{term("roles", "banned")}}}},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, config)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.ToElasticsearch(nil)
+		if err != nil {
+			t.Errorf("ToElasticsearch(%q) error: %v", tt.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ToElasticsearch(%q) = %#v; want %#v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestToElasticsearchFieldMapping(t *testing.T) {
+	r, err := ParseQuery(`name eq "Ada" and age ge 18 and price lt [d]"9.99" and bio pr`, nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	text := map[string]bool{"name": true, "bio": true}
+	query, err := r.ToElasticsearch(&ElasticsearchOptions{
+		Field: func(name string, keyword bool) (string, error) {
+			if keyword && text[name] {
+				return name + ".keyword", nil
+			}
+			return name, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("ToElasticsearch error: %v", err)
+	}
+
+	// The query encodes to the DSL, with decimals as strings and times in RFC 3339
+	got, err := json.Marshal(query)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	want := `{"bool":{"must":[{"term":{"name.keyword":{"value":"Ada"}}},{"range":{"age":{"gte":18}}},{"range":{"price":{"lt":"9.99"}}},{"exists":{"field":"bio"}}]}}`
+	if string(got) != want {
+		t.Errorf("ToElasticsearch = %s; want %s", got, want)
+	}
+
+	errRejected := errors.New("rejected")
+	_, err = r.ToElasticsearch(&ElasticsearchOptions{Field: func(string, bool) (string, error) { return "", errRejected }})
+	if !errors.Is(err, errRejected) {
+		t.Errorf("ToElasticsearch with a rejecting field mapper: error = %v; want %v", err, errRejected)
+	}
+}
+
+func TestToElasticsearchUntranslatable(t *testing.T) {
+	tests := []struct {
+		query  string
+		reason string
+	}{
+		{`get_score("math") gt 90`, "function get_score"},
+		{`price * quantity gt 1000`, "computed value"},
+		{`shipped_at gt ordered_at`, "other attributes"},
+		{`all(scores) ge 50`, "quantifier all"},
+		{`sku mr "^x"`, "mr"},
+		{`name in "abc"`, "substring"},
+		{`version gt [semver]"1.2.3"`, "Semver"},
+		{`timeout gt [dur]"1m"`, "Duration"},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		_, err = r.ToElasticsearch(nil)
+		if !errors.Is(err, ErrorUntranslatable) || !strings.Contains(err.Error(), tt.reason) {
+			t.Errorf("ToElasticsearch(%q) error = %v; want ErrorUntranslatable mentioning %q", tt.query, err, tt.reason)
+		}
+	}
+
+	var empty Rule
+	if _, err := empty.ToElasticsearch(nil); !errors.Is(err, ErrorNoExpression) {
+		t.Errorf("ToElasticsearch of an empty Rule: error = %v; want ErrorNoExpression", err)
+	}
+}
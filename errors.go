@@ -13,7 +13,7 @@ var (
 	// ErrorTypeMismatch is returned when two values fail a strict type check.
 	ErrorTypeMismatch = errors.New("compare type mismatch")
 
-	// ErrorUnknownStringOperator is returned when a string-based operator (co, sw, ew, in, pr) is unknown.
+	// ErrorUnknownStringOperator is returned when a string-based operator (co, sw, ew, in, nin, pr) is unknown.
 	ErrorUnknownStringOperator = errors.New("unknown string operator")
 
 	// ErrorNoExpression is returned when the expression tree is nil or missing.
@@ -34,6 +34,54 @@ var (
 	// ErrorInvalidOperator is returned for an unknown or unsupported operator in a comparison.
 	ErrorInvalidOperator = errors.New("invalid operator")
 
+	// ErrorInvalidStruct is returned by EvaluateStruct when the value is not a struct or a pointer to one.
+	ErrorInvalidStruct = errors.New("invalid struct")
+
+	// ErrorUnknownFunction is returned when a query calls a function that is not in the FunctionRegistry.
+	ErrorUnknownFunction = errors.New("unknown function")
+
+	// ErrorInvalidFunction is returned when a function is registered without a name or implementation.
+	ErrorInvalidFunction = errors.New("invalid function")
+
+	// ErrorFunctionAlreadyRegistered is returned when a function name is registered twice.
+	ErrorFunctionAlreadyRegistered = errors.New("function already registered")
+
+	// ErrorParameterNotFound is returned by a Resolver to report that a parameter has no value.
+	// Evaluation then treats the parameter as missing (so "pr" reports false).
+	ErrorParameterNotFound = errors.New("parameter not found")
+
+	// ErrorEvaluationAborted is returned when the context of an evaluation is canceled or its deadline passes.
+	ErrorEvaluationAborted = errors.New("evaluation aborted")
+
+	// ErrorInvalidPattern is returned by ParseQuery when the pattern of a regular expression match
+	// (mr) is not a string, does not compile or exceeds Config.MaxPatternLength.
+	ErrorInvalidPattern = errors.New("invalid pattern")
+
+	// ErrorInvalidNetwork is returned by ParseQuery when the value of a "within network" comparison (wi)
+	// is not a valid CIDR prefix or list of prefixes.
+	ErrorInvalidNetwork = errors.New("invalid network")
+
+	// ErrorInvalidSemver is returned for a malformed semantic version, e.g. in a [semver] value.
+	ErrorInvalidSemver = errors.New("invalid semantic version")
+
+	// ErrorInvalidArithmetic is returned when arithmetic is applied to values that do not support it,
+	// e.g. adding two times.
+	ErrorInvalidArithmetic = errors.New("invalid arithmetic")
+
+	// ErrorDivisionByZero is returned when an arithmetic expression divides by zero (/ or %).
+	ErrorDivisionByZero = errors.New("division by zero")
+
+	// ErrorArithmeticOverflow is returned when the result of an arithmetic expression does not fit its type.
+	ErrorArithmeticOverflow = errors.New("arithmetic overflow")
+
+	// ErrorUntranslatable is returned when a Rule cannot be translated to another query language,
+	// e.g. by Rule.ToSQL for a function call without an SQL mapping.
+	ErrorUntranslatable = errors.New("cannot translate rule")
+
+	// ErrorInvalidJSONLogic is returned by FromJSONLogic for malformed JSON Logic, or for operations
+	// that have no equivalent in a Rule.
+	ErrorInvalidJSONLogic = errors.New("invalid JSON Logic")
+
 	// ErrorSyntaxError is used for general syntax errors in the input query.
 	ErrorSyntaxError = errors.New("syntax error")
 )
@@ -43,6 +91,46 @@ func newSyntaxError(v string) error {
 	return fmt.Errorf("%w at line %v", ErrorSyntaxError, v)
 }
 
+// newErrorInvalidPattern wraps ErrorInvalidPattern with the line/column of the pattern and the reason it was rejected.
+func newErrorInvalidPattern(line, column int, reason string) error {
+	return fmt.Errorf("%w at line %d:%d: %s", ErrorInvalidPattern, line, column, reason)
+}
+
+// newErrorInvalidNetwork wraps ErrorInvalidNetwork with the line/column of the value and the reason it was rejected.
+func newErrorInvalidNetwork(line, column int, reason string) error {
+	return fmt.Errorf("%w at line %d:%d: %s", ErrorInvalidNetwork, line, column, reason)
+}
+
+// newErrorInvalidSemver constructs an error indicating why the version v is malformed.
+func newErrorInvalidSemver(v string, reason string) error {
+	return fmt.Errorf("%w %q: %s", ErrorInvalidSemver, v, reason)
+}
+
+// newErrorInvalidArithmetic constructs an error indicating op cannot be applied to the given types.
+func newErrorInvalidArithmetic(left string, op string, right string) error {
+	return fmt.Errorf("%w: %s %s %s", ErrorInvalidArithmetic, left, op, right)
+}
+
+// newErrorDivisionByZero constructs an error indicating that left was divided by zero.
+func newErrorDivisionByZero(left any, op string) error {
+	return fmt.Errorf("%w: %v %s 0", ErrorDivisionByZero, left, op)
+}
+
+// newErrorArithmeticOverflow constructs an error indicating that left op right overflows.
+func newErrorArithmeticOverflow(left any, op string, right any) error {
+	return fmt.Errorf("%w: %v %s %v", ErrorArithmeticOverflow, left, op, right)
+}
+
+// newErrorUntranslatable wraps ErrorUntranslatable with the target language and the reason.
+func newErrorUntranslatable(target string, reason string) error {
+	return fmt.Errorf("%w to %s: %s", ErrorUntranslatable, target, reason)
+}
+
+// newErrorInvalidJSONLogic wraps ErrorInvalidJSONLogic with the reason the JSON Logic was rejected.
+func newErrorInvalidJSONLogic(reason string) error {
+	return fmt.Errorf("%w: %s", ErrorInvalidJSONLogic, reason)
+}
+
 // newErrorInvalidOperator constructs an error indicating the given operator is invalid for a particular type.
 func newErrorInvalidOperator(op string, t string) error {
 	return fmt.Errorf("%w: %s on %s", ErrorInvalidOperator, op, t)
@@ -52,3 +140,28 @@ func newErrorInvalidOperator(op string, t string) error {
 func newErrorTypeMismatch(v string, v2 string) error {
 	return fmt.Errorf("%w: %s and %s", ErrorTypeMismatch, v, v2)
 }
+
+// newErrorUnknownFunction constructs an error indicating the named function is not registered.
+func newErrorUnknownFunction(name string) error {
+	return fmt.Errorf("%w: %s", ErrorUnknownFunction, name)
+}
+
+// newErrorInvalidFunction constructs an error indicating the named function cannot be registered.
+func newErrorInvalidFunction(name string, reason string) error {
+	return fmt.Errorf("%w: %q: %s", ErrorInvalidFunction, name, reason)
+}
+
+// newErrorFunctionAlreadyRegistered constructs an error indicating the named function already exists.
+func newErrorFunctionAlreadyRegistered(name string) error {
+	return fmt.Errorf("%w: %s", ErrorFunctionAlreadyRegistered, name)
+}
+
+// newErrorInvalidFunctionCall constructs an error indicating a call to the named function does not match its signature.
+func newErrorInvalidFunctionCall(name string, reason string) error {
+	return fmt.Errorf("%w: %s: %s", ErrorInvalidFunctionCall, name, reason)
+}
+
+// newErrorEvaluationAborted wraps both ErrorEvaluationAborted and the context error that caused it.
+func newErrorEvaluationAborted(ctxErr error) error {
+	return fmt.Errorf("%w: %w", ErrorEvaluationAborted, ctxErr)
+}
@@ -0,0 +1,129 @@
+package rule
+
+import (
+	"context"
+	"fmt"
+)
+
+// Func is the Go implementation of a query function. It receives the parsed argument values
+// in call order and returns the value that is compared against the right-hand side of the query.
+type Func func(args ...any) (any, error)
+
+// ContextFunc is like Func, but also receives the context of the evaluation (see Rule.EvaluateContext and friends),
+// so that blocking functions can honor cancellation and deadlines.
+type ContextFunc func(ctx context.Context, args ...any) (any, error)
+
+// FunctionRegistry holds the functions that queries may call, e.g. get_author("Book").
+// Pass it to ParseQuery through Config.Functions: ParseQuery then checks every call against the
+// registered signature, and evaluation invokes the function on demand with the parsed arguments.
+//
+// The zero value is an empty registry ready to use. A FunctionRegistry must not be modified while
+// rules compiled with it are being evaluated.
+type FunctionRegistry struct {
+	functions map[string]registeredFunction
+}
+
+// builtinFunctions are the names the query language itself calls with parentheses, e.g. now() and
+// any(tags); a registered function of the same name could never be called.
+var builtinFunctions = map[string]bool{"now": true, "any": true, "all": true, "none": true, "len": true}
+
+// registeredFunction couples a ContextFunc with its declared argument types.
+type registeredFunction struct {
+	fn       ContextFunc
+	argTypes []ArgumentType
+}
+
+// NewFunctionRegistry returns an empty FunctionRegistry.
+func NewFunctionRegistry() *FunctionRegistry {
+	return &FunctionRegistry{functions: make(map[string]registeredFunction)}
+}
+
+// Register adds fn under name. argTypes declares the expected type of each argument in order,
+// matching FunctionArgument.ArgumentType as produced by the parser (e.g. ArgTypeString for "abc",
+// ArgTypeInteger64 for 15, ArgTypeDecimal for [d]"1.5"). ArgTypeUnknown accepts any type.
+//
+// Registering a name twice returns ErrorFunctionAlreadyRegistered; an empty name, a nil fn or one
+// of the built-in names now, any, all, none and len returns ErrorInvalidFunction.
+func (r *FunctionRegistry) Register(name string, fn Func, argTypes ...ArgumentType) error {
+	if fn == nil {
+		return r.RegisterContext(name, nil, argTypes...)
+	}
+	return r.RegisterContext(name, func(_ context.Context, args ...any) (any, error) {
+		return fn(args...)
+	}, argTypes...)
+}
+
+// RegisterContext is like Register for a function that needs the evaluation context.
+func (r *FunctionRegistry) RegisterContext(name string, fn ContextFunc, argTypes ...ArgumentType) error {
+	if name == "" || fn == nil {
+		return newErrorInvalidFunction(name, "a function needs a name and an implementation")
+	}
+	if builtinFunctions[name] {
+		return newErrorInvalidFunction(name, "the name is reserved for a built-in")
+	}
+	if _, ok := r.functions[name]; ok {
+		return newErrorFunctionAlreadyRegistered(name)
+	}
+	if r.functions == nil {
+		r.functions = make(map[string]registeredFunction)
+	}
+	r.functions[name] = registeredFunction{
+		fn:       fn,
+		argTypes: append([]ArgumentType(nil), argTypes...),
+	}
+	return nil
+}
+
+// validate checks the arguments of a parsed function call against the registered signature.
+func (r *FunctionRegistry) validate(p *Parameter) error {
+	f, ok := r.functions[p.Name]
+	if !ok {
+		return newErrorUnknownFunction(p.Name)
+	}
+	if len(p.FunctionArguments) != len(f.argTypes) {
+		return newErrorInvalidFunctionCall(p.Name, fmt.Sprintf("expected %d argument(s), got %d", len(f.argTypes), len(p.FunctionArguments)))
+	}
+	for i, arg := range p.FunctionArguments {
+		want := f.argTypes[i]
+		if want != ArgTypeUnknown && want != arg.ArgumentType {
+			return newErrorInvalidFunctionCall(p.Name, fmt.Sprintf("argument %d must be %s, got %s", i+1, want, arg.ArgumentType))
+		}
+	}
+	return nil
+}
+
+// validateAll validates every function call in params, including those in value path filters.
+func (r *FunctionRegistry) validateAll(params []Parameter) error {
+	for i := range params {
+		if params[i].Filter != nil {
+			if err := r.validateAll(params[i].Filter.Params); err != nil {
+				return err
+			}
+		}
+		if params[i].InputType != FunctionCall {
+			continue
+		}
+		if err := r.validate(&params[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// call invokes the function referenced by p with its parsed arguments.
+// The second return value is false if no function with that name is registered.
+func (r *FunctionRegistry) call(ctx context.Context, p *Parameter) (any, bool, error) {
+	f, ok := r.functions[p.Name]
+	if !ok {
+		return nil, false, nil
+	}
+	args := make([]any, len(p.FunctionArguments))
+	for i, arg := range p.FunctionArguments {
+		args[i] = arg.Value
+	}
+	out, err := f.fn(ctx, args...)
+	if err != nil {
+		return nil, true, fmt.Errorf("%s: %w", p.Name, err)
+	}
+	return out, true, nil
+}
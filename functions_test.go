@@ -0,0 +1,181 @@
+package rule
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newTestRegistry(t *testing.T) *FunctionRegistry {
+	t.Helper()
+	reg := NewFunctionRegistry()
+	authors := map[string]string{"Song of Myself": "Walt Whitman"}
+	err := reg.Register("get_author", func(args ...any) (any, error) {
+		author, ok := authors[args[0].(string)]
+		if !ok {
+			return nil, errors.New("no such book")
+		}
+		return author, nil
+	}, ArgTypeString)
+	if err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	err = reg.Register("add", func(args ...any) (any, error) {
+		return args[0].(int64) + args[1].(int64), nil
+	}, ArgTypeInteger64, ArgTypeInteger64)
+	if err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	err = reg.Register("echo", func(args ...any) (any, error) {
+		return args[0], nil
+	}, ArgTypeUnknown)
+	if err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	return reg
+}
+
+func TestFunctionRegistryRegister(t *testing.T) {
+	reg := newTestRegistry(t)
+	if err := reg.Register("add", func(args ...any) (any, error) { return nil, nil }); !errors.Is(err, ErrorFunctionAlreadyRegistered) {
+		t.Errorf("expected ErrorFunctionAlreadyRegistered, got %v", err)
+	}
+	if err := reg.Register("", func(args ...any) (any, error) { return nil, nil }); !errors.Is(err, ErrorInvalidFunction) {
+		t.Errorf("expected ErrorInvalidFunction for empty name, got %v", err)
+	}
+	if err := reg.Register("nil_func", nil); !errors.Is(err, ErrorInvalidFunction) {
+		t.Errorf("expected ErrorInvalidFunction for nil function, got %v", err)
+	}
+	for _, name := range []string{"now", "any", "all", "none", "len"} {
+		if err := reg.Register(name, func(args ...any) (any, error) { return nil, nil }); !errors.Is(err, ErrorInvalidFunction) {
+			t.Errorf("expected ErrorInvalidFunction for the built-in %s, got %v", name, err)
+		}
+	}
+}
+
+func TestFunctionRegistryZeroValue(t *testing.T) {
+	var reg FunctionRegistry
+	if _, err := ParseQuery(`unknown_func(1) eq 1`, &Config{Functions: &reg}); !errors.Is(err, ErrorUnknownFunction) {
+		t.Fatalf("ParseQuery with an empty registry: error = %v; want ErrorUnknownFunction", err)
+	}
+
+	if err := reg.Register("double", func(args ...any) (any, error) { return args[0].(int64) * 2, nil }, ArgTypeInteger64); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	r, err := ParseQuery(`double(21) eq 42`, &Config{Functions: &reg})
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if got, err := r.Evaluate(nil); err != nil || !got {
+		t.Errorf("Evaluate = %v, %v; want true, nil", got, err)
+	}
+}
+
+func TestParseQueryValidatesFunctions(t *testing.T) {
+	cfg := &Config{Functions: newTestRegistry(t)}
+	tests := []struct {
+		query   string
+		wantErr error
+		errText string
+	}{
+		{`get_author("Song of Myself") eq "Walt Whitman"`, nil, ""},
+		{`add(1, 2) eq 3`, nil, ""},
+		{`echo([d]"1.5") eq 1`, nil, ""},
+		{`unknown_func(1) eq 1`, ErrorUnknownFunction, "unknown_func"},
+		{`add(1) eq 3`, ErrorInvalidFunctionCall, "expected 2 argument(s), got 1"},
+		{`add(1, "2") eq 3`, ErrorInvalidFunctionCall, "argument 2 must be int64, got string"},
+		{`unknown_func(1) pr`, ErrorUnknownFunction, "unknown_func"},
+		{`not add(1) pr`, ErrorInvalidFunctionCall, "expected 2 argument(s), got 1"},
+		{`a eq 1 and now() pr`, ErrorSyntaxError, "1:11: pr must refer to an attribute or a function call"},
+	}
+	for _, tt := range tests {
+		_, err := ParseQuery(tt.query, cfg)
+		if tt.wantErr == nil {
+			if err != nil {
+				t.Errorf("ParseQuery(%q) unexpected error: %v", tt.query, err)
+			}
+			continue
+		}
+		if !errors.Is(err, tt.wantErr) || !strings.Contains(err.Error(), tt.errText) {
+			t.Errorf("ParseQuery(%q) error = %v; want %v containing %q", tt.query, err, tt.wantErr, tt.errText)
+		}
+	}
+
+	// Without a registry, function calls are not validated.
+	if _, err := ParseQuery(`unknown_func(1) eq 1`, nil); err != nil {
+		t.Errorf("ParseQuery without registry unexpected error: %v", err)
+	}
+}
+
+func TestEvaluateRegisteredFunctions(t *testing.T) {
+	cfg := &Config{Functions: newTestRegistry(t)}
+	tests := []struct {
+		query string
+		doc   map[string]any
+		want  bool
+	}{
+		{`get_author("Song of Myself") eq "Walt Whitman"`, nil, true},
+		{`add(1, 2) eq 3 and add(2, 2) gt 3`, nil, true},
+		{`echo(42) eq 1 or (age eq 42 and add(40, 2) eq 42)`, map[string]any{"age": 42}, true},
+		{`not (echo("x") eq "x")`, nil, false},
+		{`get_author("Song of Myself") pr and not add(1, 2) pr`, nil, false},
+		{`echo("x") pr and not echo(1) pr`, nil, false},
+		{`add(1, 2) pr`, nil, true},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, cfg)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.EvaluateMap(tt.doc)
+		if err != nil {
+			t.Fatalf("EvaluateMap(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateMap(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateRegisteredFunctionCalls(t *testing.T) {
+	calls := 0
+	reg := NewFunctionRegistry()
+	if err := reg.Register("f", func(args ...any) (any, error) {
+		calls++
+		return int64(1), nil
+	}); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+
+	r, err := ParseQuery(`f() eq 2 or f() eq 3 or f() eq 1`, &Config{Functions: reg})
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	got, err := r.Evaluate(nil)
+	if err != nil || !got {
+		t.Fatalf("Evaluate = %v, %v; want true, nil", got, err)
+	}
+	if calls != 1 {
+		t.Errorf("expected f to be called once, got %d", calls)
+	}
+}
+
+func TestEvaluateFunctionOverridesAndErrors(t *testing.T) {
+	cfg := &Config{Functions: newTestRegistry(t)}
+	r, err := ParseQuery(`get_author("Leaves of Grass") eq "Walt Whitman"`, cfg)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	// A supplied Evaluation wins over the registered function.
+	got, err := r.Evaluate([]Evaluation{{Param: r.Params[0], Result: "Walt Whitman"}})
+	if err != nil || !got {
+		t.Errorf("Evaluate with supplied result = %v, %v; want true, nil", got, err)
+	}
+
+	// Otherwise the function runs, and its error is returned.
+	_, err = r.Evaluate(nil)
+	if err == nil || !strings.Contains(err.Error(), "get_author: no such book") {
+		t.Errorf("expected function error, got %v", err)
+	}
+}
@@ -5,35 +5,56 @@ root
   ;
 
 query
-  : NOT? SP? LPAREN SP? query SP? RPAREN  #parenExp
-  | query SP LOGICAL_OPERATOR SP query    #logicalExp
-  | attrPath SP 'pr'                      #presentExp
-  | (attrPath | functionCall) SP op=(EQ|NE|GT|LT|GE|LE|CO|SW|EW|IN) SP value #compareExp
+  : NOT? LPAREN query RPAREN              #parenExp
+  | query op=AND query                    #logicalExp
+  | query op=OR query                     #logicalExp
+  | NOT? attrPath PR                      #presentExp
+  | NOT? attrPath '[' query ']'           #valuePathExp
+  | NOT? value op=(EQ|NE|GT|LT|GE|LE|CO|SW|EW|IN|NIN|MR|WI) value #compareExp
   ;
 
-NOT : 'not' | 'NOT' ;
-LOGICAL_OPERATOR : 'and' | 'or' ;
+// Logical and comparison keywords are case-insensitive, as in SCIM.
+NOT : N O T ;
+AND : A N D ;
+OR : O R ;
 
 BOOLEAN : 'true' | 'false' ;
 NULL : 'null' | 'nil' ;
-IN : 'IN' | 'in';
-EQ : 'eq' | 'EQ';
-NE : 'ne' | 'NE';
-GT : 'gt' | 'GT';
-LT : 'lt' | 'LT';
-GE : 'ge' | 'GE';
-LE : 'le' | 'LE';
-CO : 'co' | 'CO';
-SW : 'sw' | 'SW';
-EW : 'ew' | 'EW';
+IN : I N ;
+NIN : N I N ;
+EQ : E Q ;
+NE : N E ;
+GT : G T ;
+LT : L T ;
+GE : G E ;
+LE : L E ;
+CO : C O ;
+SW : S W ;
+EW : E W ;
+MR : M R | M A T C H ;
+PR : P R ;
+WI : W I | W I T H I N ;
+
+// Built-in quantifiers and length of multi-valued attributes; they remain valid attribute names (see attrName).
+ANY : 'any' ;
+ALL : 'all' ;
+NONE : 'none' ;
+LEN : 'len' ;
 
 attrPath
-   : ATTRNAME subAttr?
+   : attrName subAttr?
    | functionCall
    ;
 
+// Operator keywords, quantifiers and len remain valid attribute names, e.g. "match eq 1" or "spec.in pr".
+// Only the logical keywords (and, or, not), booleans and null are reserved.
+attrName
+   : ATTRNAME | ANY | ALL | NONE | LEN
+   | IN | NIN | EQ | NE | GT | LT | GE | LE | CO | SW | EW | MR | PR | WI
+   ;
+
 typeAnnotation
-  : '[f64]' | '[i64]' | '[ui64]' | '[i]' | '[ui]' | '[i32]' | '[ui32]' | '[d]' | '[s]' | '[f32]'
+  : '[f64]' | '[i64]' | '[ui64]' | '[i]' | '[ui]' | '[i32]' | '[ui32]' | '[d]' | '[s]' | '[f32]' | '[t]' | '[date]' | '[dur]' | '[ip]' | '[cidr]' | '[semver]'
   ;
 
 functionCall
@@ -63,9 +84,27 @@ fragment ALPHA
    : [A-Za-z]
    ;
 
+fragment A : [aA] ;
+fragment C : [cC] ;
+fragment D : [dD] ;
+fragment E : [eE] ;
+fragment G : [gG] ;
+fragment H : [hH] ;
+fragment I : [iI] ;
+fragment L : [lL] ;
+fragment M : [mM] ;
+fragment N : [nN] ;
+fragment O : [oO] ;
+fragment P : [pP] ;
+fragment Q : [qQ] ;
+fragment R : [rR] ;
+fragment S : [sS] ;
+fragment T : [tT] ;
+fragment W : [wW] ;
+
 typedValue
    : typeAnnotation? STRING           #typedString
-   | typeAnnotation? DOUBLE           #typedDouble
+   | typeAnnotation? '-'? DOUBLE      #typedDouble
    | typeAnnotation? '-'? INT EXP?    #typedInteger
    ;
 
@@ -73,13 +112,21 @@ value
    : typedValue        #typedVal
    | BOOLEAN           #boolean
    | NULL              #null
-   | listInts          #listOfInts
-   | listDoubles       #listOfDoubles
-   | listStrings       #listOfStrings
+   | typeAnnotation? listInts     #listOfInts
+   | typeAnnotation? listDoubles  #listOfDoubles
+   | typeAnnotation? listStrings  #listOfStrings
+   | DURATION                     #durationVal
+   | op=(ANY | ALL | NONE) LPAREN attrPath RPAREN #quantifierVal
+   | LEN LPAREN attrPath RPAREN   #lenVal
+   | attrPath                     #attrVal
+   | LPAREN value RPAREN          #parenVal
+   | value op=(STAR | SLASH | PERCENT) value #arithmeticVal
+   | value op=(PLUS | MINUS) value #arithmeticVal
    ;
 
 STRING
    : '"' (ESC | ~ ["\\])* '"'
+   | '\'' (ESC | ~ ['\\])* '\''
    ;
 
 listStrings
@@ -91,16 +138,14 @@ subListOfStrings
    | STRING ']'
    ;
 
+// ESC takes any escaped character, so that unquoteString can report an invalid escape with its position.
 fragment ESC
-   : '\\' (["\\/bfnrt] | UNICODE)
-   ;
-
-fragment UNICODE
-   : 'u' [0-9a-fA-F]
+   : '\\' .
    ;
 
+// DOUBLE has no sign, so that "x -1.5" is a subtraction; negative values are a '-' followed by a DOUBLE.
 DOUBLE
-   : '-'? INT '.' [0-9]+ EXP?
+   : INT '.' [0-9]+ EXP?
    ;
 
 listDoubles
@@ -108,8 +153,8 @@ listDoubles
    ;
 
 subListOfDoubles
-   : DOUBLE COMMA subListOfDoubles
-   | DOUBLE ']'
+   : '-'? DOUBLE COMMA subListOfDoubles
+   | '-'? DOUBLE ']'
    ;
 
 listInts
@@ -117,8 +162,21 @@ listInts
    ;
 
 subListOfInts
-   : INT COMMA subListOfInts
-   | INT ']'
+   : '-'? INT COMMA subListOfInts
+   | '-'? INT ']'
+   ;
+
+// DURATION e.g. 30d, 1h30m or 500ms; d (24h) and w (7d) extend the units of time.ParseDuration.
+DURATION
+   : DURATION_PART+
+   ;
+
+fragment DURATION_PART
+   : [0-9]+ ('.' [0-9]+)? DURATION_UNIT
+   ;
+
+fragment DURATION_UNIT
+   : 'ns' | 'us' | 'ms' | 's' | 'm' | 'h' | 'd' | 'w'
    ;
 
 // INT no leading zeros.
@@ -126,6 +184,11 @@ INT
    : '0' | [1-9] [0-9]*
    ;
 
+STAR : '*' ;
+SLASH : '/' ;
+PERCENT : '%' ;
+PLUS : '+' ;
+MINUS : '-' ;
 LPAREN : '(' ;
 RPAREN : ')' ;
 
@@ -134,12 +197,10 @@ EXP
    : [Ee] [+\-]? INT
    ;
 
-NEWLINE
-   : '\n' ;
-
 COMMA
-   : ',' ' '*;
+   : ',' ;
 
-SP
-   : ' ' NEWLINE*
+// WS between tokens is insignificant, so rules may use tabs, CRLF line endings or span several lines.
+WS
+   : [ \t\r\n]+ -> skip
    ;
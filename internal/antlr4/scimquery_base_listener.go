@@ -45,6 +45,12 @@ func (s *BaseSCIMQueryListener) EnterPresentExp(ctx *PresentExpContext) {}
 // ExitPresentExp is called when production presentExp is exited.
 func (s *BaseSCIMQueryListener) ExitPresentExp(ctx *PresentExpContext) {}
 
+// EnterValuePathExp is called when production valuePathExp is entered.
+func (s *BaseSCIMQueryListener) EnterValuePathExp(ctx *ValuePathExpContext) {}
+
+// ExitValuePathExp is called when production valuePathExp is exited.
+func (s *BaseSCIMQueryListener) ExitValuePathExp(ctx *ValuePathExpContext) {}
+
 // EnterLogicalExp is called when production logicalExp is entered.
 func (s *BaseSCIMQueryListener) EnterLogicalExp(ctx *LogicalExpContext) {}
 
@@ -57,6 +63,12 @@ func (s *BaseSCIMQueryListener) EnterAttrPath(ctx *AttrPathContext) {}
 // ExitAttrPath is called when production attrPath is exited.
 func (s *BaseSCIMQueryListener) ExitAttrPath(ctx *AttrPathContext) {}
 
+// EnterAttrName is called when production attrName is entered.
+func (s *BaseSCIMQueryListener) EnterAttrName(ctx *AttrNameContext) {}
+
+// ExitAttrName is called when production attrName is exited.
+func (s *BaseSCIMQueryListener) ExitAttrName(ctx *AttrNameContext) {}
+
 // EnterTypeAnnotation is called when production typeAnnotation is entered.
 func (s *BaseSCIMQueryListener) EnterTypeAnnotation(ctx *TypeAnnotationContext) {}
 
@@ -105,6 +117,24 @@ func (s *BaseSCIMQueryListener) EnterTypedVal(ctx *TypedValContext) {}
 // ExitTypedVal is called when production typedVal is exited.
 func (s *BaseSCIMQueryListener) ExitTypedVal(ctx *TypedValContext) {}
 
+// EnterListOfDoubles is called when production listOfDoubles is entered.
+func (s *BaseSCIMQueryListener) EnterListOfDoubles(ctx *ListOfDoublesContext) {}
+
+// ExitListOfDoubles is called when production listOfDoubles is exited.
+func (s *BaseSCIMQueryListener) ExitListOfDoubles(ctx *ListOfDoublesContext) {}
+
+// EnterListOfStrings is called when production listOfStrings is entered.
+func (s *BaseSCIMQueryListener) EnterListOfStrings(ctx *ListOfStringsContext) {}
+
+// ExitListOfStrings is called when production listOfStrings is exited.
+func (s *BaseSCIMQueryListener) ExitListOfStrings(ctx *ListOfStringsContext) {}
+
+// EnterLenVal is called when production lenVal is entered.
+func (s *BaseSCIMQueryListener) EnterLenVal(ctx *LenValContext) {}
+
+// ExitLenVal is called when production lenVal is exited.
+func (s *BaseSCIMQueryListener) ExitLenVal(ctx *LenValContext) {}
+
 // EnterBoolean is called when production boolean is entered.
 func (s *BaseSCIMQueryListener) EnterBoolean(ctx *BooleanContext) {}
 
@@ -117,23 +147,41 @@ func (s *BaseSCIMQueryListener) EnterNull(ctx *NullContext) {}
 // ExitNull is called when production null is exited.
 func (s *BaseSCIMQueryListener) ExitNull(ctx *NullContext) {}
 
-// EnterListOfInts is called when production listOfInts is entered.
-func (s *BaseSCIMQueryListener) EnterListOfInts(ctx *ListOfIntsContext) {}
+// EnterAttrVal is called when production attrVal is entered.
+func (s *BaseSCIMQueryListener) EnterAttrVal(ctx *AttrValContext) {}
 
-// ExitListOfInts is called when production listOfInts is exited.
-func (s *BaseSCIMQueryListener) ExitListOfInts(ctx *ListOfIntsContext) {}
+// ExitAttrVal is called when production attrVal is exited.
+func (s *BaseSCIMQueryListener) ExitAttrVal(ctx *AttrValContext) {}
 
-// EnterListOfDoubles is called when production listOfDoubles is entered.
-func (s *BaseSCIMQueryListener) EnterListOfDoubles(ctx *ListOfDoublesContext) {}
+// EnterArithmeticVal is called when production arithmeticVal is entered.
+func (s *BaseSCIMQueryListener) EnterArithmeticVal(ctx *ArithmeticValContext) {}
 
-// ExitListOfDoubles is called when production listOfDoubles is exited.
-func (s *BaseSCIMQueryListener) ExitListOfDoubles(ctx *ListOfDoublesContext) {}
+// ExitArithmeticVal is called when production arithmeticVal is exited.
+func (s *BaseSCIMQueryListener) ExitArithmeticVal(ctx *ArithmeticValContext) {}
 
-// EnterListOfStrings is called when production listOfStrings is entered.
-func (s *BaseSCIMQueryListener) EnterListOfStrings(ctx *ListOfStringsContext) {}
+// EnterDurationVal is called when production durationVal is entered.
+func (s *BaseSCIMQueryListener) EnterDurationVal(ctx *DurationValContext) {}
 
-// ExitListOfStrings is called when production listOfStrings is exited.
-func (s *BaseSCIMQueryListener) ExitListOfStrings(ctx *ListOfStringsContext) {}
+// ExitDurationVal is called when production durationVal is exited.
+func (s *BaseSCIMQueryListener) ExitDurationVal(ctx *DurationValContext) {}
+
+// EnterQuantifierVal is called when production quantifierVal is entered.
+func (s *BaseSCIMQueryListener) EnterQuantifierVal(ctx *QuantifierValContext) {}
+
+// ExitQuantifierVal is called when production quantifierVal is exited.
+func (s *BaseSCIMQueryListener) ExitQuantifierVal(ctx *QuantifierValContext) {}
+
+// EnterParenVal is called when production parenVal is entered.
+func (s *BaseSCIMQueryListener) EnterParenVal(ctx *ParenValContext) {}
+
+// ExitParenVal is called when production parenVal is exited.
+func (s *BaseSCIMQueryListener) ExitParenVal(ctx *ParenValContext) {}
+
+// EnterListOfInts is called when production listOfInts is entered.
+func (s *BaseSCIMQueryListener) EnterListOfInts(ctx *ListOfIntsContext) {}
+
+// ExitListOfInts is called when production listOfInts is exited.
+func (s *BaseSCIMQueryListener) ExitListOfInts(ctx *ListOfIntsContext) {}
 
 // EnterListStrings is called when production listStrings is entered.
 func (s *BaseSCIMQueryListener) EnterListStrings(ctx *ListStringsContext) {}
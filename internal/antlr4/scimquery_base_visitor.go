@@ -24,6 +24,10 @@ func (v *BaseSCIMQueryVisitor) VisitPresentExp(ctx *PresentExpContext) interface
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseSCIMQueryVisitor) VisitValuePathExp(ctx *ValuePathExpContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseSCIMQueryVisitor) VisitLogicalExp(ctx *LogicalExpContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -32,6 +36,10 @@ func (v *BaseSCIMQueryVisitor) VisitAttrPath(ctx *AttrPathContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseSCIMQueryVisitor) VisitAttrName(ctx *AttrNameContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseSCIMQueryVisitor) VisitTypeAnnotation(ctx *TypeAnnotationContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -64,6 +72,18 @@ func (v *BaseSCIMQueryVisitor) VisitTypedVal(ctx *TypedValContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseSCIMQueryVisitor) VisitListOfDoubles(ctx *ListOfDoublesContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSCIMQueryVisitor) VisitListOfStrings(ctx *ListOfStringsContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSCIMQueryVisitor) VisitLenVal(ctx *LenValContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseSCIMQueryVisitor) VisitBoolean(ctx *BooleanContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -72,15 +92,27 @@ func (v *BaseSCIMQueryVisitor) VisitNull(ctx *NullContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
-func (v *BaseSCIMQueryVisitor) VisitListOfInts(ctx *ListOfIntsContext) interface{} {
+func (v *BaseSCIMQueryVisitor) VisitAttrVal(ctx *AttrValContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
-func (v *BaseSCIMQueryVisitor) VisitListOfDoubles(ctx *ListOfDoublesContext) interface{} {
+func (v *BaseSCIMQueryVisitor) VisitArithmeticVal(ctx *ArithmeticValContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
-func (v *BaseSCIMQueryVisitor) VisitListOfStrings(ctx *ListOfStringsContext) interface{} {
+func (v *BaseSCIMQueryVisitor) VisitDurationVal(ctx *DurationValContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSCIMQueryVisitor) VisitQuantifierVal(ctx *QuantifierValContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSCIMQueryVisitor) VisitParenVal(ctx *ParenValContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseSCIMQueryVisitor) VisitListOfInts(ctx *ListOfIntsContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
@@ -43,28 +43,35 @@ func scimquerylexerLexerInit() {
 		"DEFAULT_MODE",
 	}
 	staticData.LiteralNames = []string{
-		"", "'pr'", "'[f64]'", "'[i64]'", "'[ui64]'", "'[i]'", "'[ui]'", "'[i32]'",
-		"'[ui32]'", "'[d]'", "'[s]'", "'[f32]'", "'.'", "'-'", "'['", "']'",
-		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
-		"", "'('", "')'", "", "'\\n'",
+		"", "'['", "']'", "'[f64]'", "'[i64]'", "'[ui64]'", "'[i]'", "'[ui]'",
+		"'[i32]'", "'[ui32]'", "'[d]'", "'[s]'", "'[f32]'", "'[t]'", "'[date]'",
+		"'[dur]'", "'[ip]'", "'[cidr]'", "'[semver]'", "'.'", "", "", "", "",
+		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "'any'",
+		"'all'", "'none'", "'len'", "", "", "", "", "", "'*'", "'/'", "'%'",
+		"'+'", "'-'", "'('", "')'", "", "','",
 	}
 	staticData.SymbolicNames = []string{
-		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "NOT",
-		"LOGICAL_OPERATOR", "BOOLEAN", "NULL", "IN", "EQ", "NE", "GT", "LT",
-		"GE", "LE", "CO", "SW", "EW", "ATTRNAME", "STRING", "DOUBLE", "INT",
-		"LPAREN", "RPAREN", "EXP", "NEWLINE", "COMMA", "SP",
+		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
+		"", "", "", "NOT", "AND", "OR", "BOOLEAN", "NULL", "IN", "NIN", "EQ",
+		"NE", "GT", "LT", "GE", "LE", "CO", "SW", "EW", "MR", "PR", "WI", "ANY",
+		"ALL", "NONE", "LEN", "ATTRNAME", "STRING", "DOUBLE", "DURATION", "INT",
+		"STAR", "SLASH", "PERCENT", "PLUS", "MINUS", "LPAREN", "RPAREN", "EXP",
+		"COMMA", "WS",
 	}
 	staticData.RuleNames = []string{
 		"T__0", "T__1", "T__2", "T__3", "T__4", "T__5", "T__6", "T__7", "T__8",
-		"T__9", "T__10", "T__11", "T__12", "T__13", "T__14", "NOT", "LOGICAL_OPERATOR",
-		"BOOLEAN", "NULL", "IN", "EQ", "NE", "GT", "LT", "GE", "LE", "CO", "SW",
-		"EW", "ATTRNAME", "ATTR_NAME_CHAR", "DIGIT", "ALPHA", "STRING", "ESC",
-		"UNICODE", "DOUBLE", "INT", "LPAREN", "RPAREN", "EXP", "NEWLINE", "COMMA",
-		"SP",
+		"T__9", "T__10", "T__11", "T__12", "T__13", "T__14", "T__15", "T__16",
+		"T__17", "T__18", "NOT", "AND", "OR", "BOOLEAN", "NULL", "IN", "NIN",
+		"EQ", "NE", "GT", "LT", "GE", "LE", "CO", "SW", "EW", "MR", "PR", "WI",
+		"ANY", "ALL", "NONE", "LEN", "ATTRNAME", "ATTR_NAME_CHAR", "DIGIT",
+		"ALPHA", "A", "C", "D", "E", "G", "H", "I", "L", "M", "N", "O", "P",
+		"Q", "R", "S", "T", "W", "STRING", "ESC", "DOUBLE", "DURATION", "DURATION_PART",
+		"DURATION_UNIT", "INT", "STAR", "SLASH", "PERCENT", "PLUS", "MINUS",
+		"LPAREN", "RPAREN", "EXP", "COMMA", "WS",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 0, 39, 333, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
+		4, 0, 57, 519, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
 		4, 7, 4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2,
 		10, 7, 10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15,
 		7, 15, 2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7,
@@ -72,150 +79,229 @@ func scimquerylexerLexerInit() {
 		2, 26, 7, 26, 2, 27, 7, 27, 2, 28, 7, 28, 2, 29, 7, 29, 2, 30, 7, 30, 2,
 		31, 7, 31, 2, 32, 7, 32, 2, 33, 7, 33, 2, 34, 7, 34, 2, 35, 7, 35, 2, 36,
 		7, 36, 2, 37, 7, 37, 2, 38, 7, 38, 2, 39, 7, 39, 2, 40, 7, 40, 2, 41, 7,
-		41, 2, 42, 7, 42, 2, 43, 7, 43, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1,
-		1, 1, 1, 1, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 3, 1, 3, 1, 3, 1,
-		3, 1, 3, 1, 3, 1, 3, 1, 4, 1, 4, 1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 1, 5, 1,
-		5, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1,
-		7, 1, 7, 1, 8, 1, 8, 1, 8, 1, 8, 1, 9, 1, 9, 1, 9, 1, 9, 1, 10, 1, 10,
-		1, 10, 1, 10, 1, 10, 1, 10, 1, 11, 1, 11, 1, 12, 1, 12, 1, 13, 1, 13, 1,
-		14, 1, 14, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 3, 15, 162, 8, 15,
-		1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 3, 16, 169, 8, 16, 1, 17, 1, 17, 1,
-		17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 3, 17, 180, 8, 17, 1, 18,
-		1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 3, 18, 189, 8, 18, 1, 19, 1,
-		19, 1, 19, 1, 19, 3, 19, 195, 8, 19, 1, 20, 1, 20, 1, 20, 1, 20, 3, 20,
-		201, 8, 20, 1, 21, 1, 21, 1, 21, 1, 21, 3, 21, 207, 8, 21, 1, 22, 1, 22,
-		1, 22, 1, 22, 3, 22, 213, 8, 22, 1, 23, 1, 23, 1, 23, 1, 23, 3, 23, 219,
-		8, 23, 1, 24, 1, 24, 1, 24, 1, 24, 3, 24, 225, 8, 24, 1, 25, 1, 25, 1,
-		25, 1, 25, 3, 25, 231, 8, 25, 1, 26, 1, 26, 1, 26, 1, 26, 3, 26, 237, 8,
-		26, 1, 27, 1, 27, 1, 27, 1, 27, 3, 27, 243, 8, 27, 1, 28, 1, 28, 1, 28,
-		1, 28, 3, 28, 249, 8, 28, 1, 29, 1, 29, 5, 29, 253, 8, 29, 10, 29, 12,
-		29, 256, 9, 29, 1, 30, 1, 30, 1, 30, 3, 30, 261, 8, 30, 1, 31, 1, 31, 1,
-		32, 1, 32, 1, 33, 1, 33, 1, 33, 5, 33, 270, 8, 33, 10, 33, 12, 33, 273,
-		9, 33, 1, 33, 1, 33, 1, 34, 1, 34, 1, 34, 3, 34, 280, 8, 34, 1, 35, 1,
-		35, 1, 35, 1, 36, 3, 36, 286, 8, 36, 1, 36, 1, 36, 1, 36, 4, 36, 291, 8,
-		36, 11, 36, 12, 36, 292, 1, 36, 3, 36, 296, 8, 36, 1, 37, 1, 37, 1, 37,
-		5, 37, 301, 8, 37, 10, 37, 12, 37, 304, 9, 37, 3, 37, 306, 8, 37, 1, 38,
-		1, 38, 1, 39, 1, 39, 1, 40, 1, 40, 3, 40, 314, 8, 40, 1, 40, 1, 40, 1,
-		41, 1, 41, 1, 42, 1, 42, 5, 42, 322, 8, 42, 10, 42, 12, 42, 325, 9, 42,
-		1, 43, 1, 43, 5, 43, 329, 8, 43, 10, 43, 12, 43, 332, 9, 43, 0, 0, 44,
-		1, 1, 3, 2, 5, 3, 7, 4, 9, 5, 11, 6, 13, 7, 15, 8, 17, 9, 19, 10, 21, 11,
-		23, 12, 25, 13, 27, 14, 29, 15, 31, 16, 33, 17, 35, 18, 37, 19, 39, 20,
-		41, 21, 43, 22, 45, 23, 47, 24, 49, 25, 51, 26, 53, 27, 55, 28, 57, 29,
-		59, 30, 61, 0, 63, 0, 65, 0, 67, 31, 69, 0, 71, 0, 73, 32, 75, 33, 77,
-		34, 79, 35, 81, 36, 83, 37, 85, 38, 87, 39, 1, 0, 9, 3, 0, 45, 45, 58,
-		58, 95, 95, 1, 0, 48, 57, 2, 0, 65, 90, 97, 122, 2, 0, 34, 34, 92, 92,
-		8, 0, 34, 34, 47, 47, 92, 92, 98, 98, 102, 102, 110, 110, 114, 114, 116,
-		116, 3, 0, 48, 57, 65, 70, 97, 102, 1, 0, 49, 57, 2, 0, 69, 69, 101, 101,
-		2, 0, 43, 43, 45, 45, 355, 0, 1, 1, 0, 0, 0, 0, 3, 1, 0, 0, 0, 0, 5, 1,
-		0, 0, 0, 0, 7, 1, 0, 0, 0, 0, 9, 1, 0, 0, 0, 0, 11, 1, 0, 0, 0, 0, 13,
-		1, 0, 0, 0, 0, 15, 1, 0, 0, 0, 0, 17, 1, 0, 0, 0, 0, 19, 1, 0, 0, 0, 0,
-		21, 1, 0, 0, 0, 0, 23, 1, 0, 0, 0, 0, 25, 1, 0, 0, 0, 0, 27, 1, 0, 0, 0,
-		0, 29, 1, 0, 0, 0, 0, 31, 1, 0, 0, 0, 0, 33, 1, 0, 0, 0, 0, 35, 1, 0, 0,
-		0, 0, 37, 1, 0, 0, 0, 0, 39, 1, 0, 0, 0, 0, 41, 1, 0, 0, 0, 0, 43, 1, 0,
-		0, 0, 0, 45, 1, 0, 0, 0, 0, 47, 1, 0, 0, 0, 0, 49, 1, 0, 0, 0, 0, 51, 1,
-		0, 0, 0, 0, 53, 1, 0, 0, 0, 0, 55, 1, 0, 0, 0, 0, 57, 1, 0, 0, 0, 0, 59,
-		1, 0, 0, 0, 0, 67, 1, 0, 0, 0, 0, 73, 1, 0, 0, 0, 0, 75, 1, 0, 0, 0, 0,
-		77, 1, 0, 0, 0, 0, 79, 1, 0, 0, 0, 0, 81, 1, 0, 0, 0, 0, 83, 1, 0, 0, 0,
-		0, 85, 1, 0, 0, 0, 0, 87, 1, 0, 0, 0, 1, 89, 1, 0, 0, 0, 3, 92, 1, 0, 0,
-		0, 5, 98, 1, 0, 0, 0, 7, 104, 1, 0, 0, 0, 9, 111, 1, 0, 0, 0, 11, 115,
-		1, 0, 0, 0, 13, 120, 1, 0, 0, 0, 15, 126, 1, 0, 0, 0, 17, 133, 1, 0, 0,
-		0, 19, 137, 1, 0, 0, 0, 21, 141, 1, 0, 0, 0, 23, 147, 1, 0, 0, 0, 25, 149,
-		1, 0, 0, 0, 27, 151, 1, 0, 0, 0, 29, 153, 1, 0, 0, 0, 31, 161, 1, 0, 0,
-		0, 33, 168, 1, 0, 0, 0, 35, 179, 1, 0, 0, 0, 37, 188, 1, 0, 0, 0, 39, 194,
-		1, 0, 0, 0, 41, 200, 1, 0, 0, 0, 43, 206, 1, 0, 0, 0, 45, 212, 1, 0, 0,
-		0, 47, 218, 1, 0, 0, 0, 49, 224, 1, 0, 0, 0, 51, 230, 1, 0, 0, 0, 53, 236,
-		1, 0, 0, 0, 55, 242, 1, 0, 0, 0, 57, 248, 1, 0, 0, 0, 59, 250, 1, 0, 0,
-		0, 61, 260, 1, 0, 0, 0, 63, 262, 1, 0, 0, 0, 65, 264, 1, 0, 0, 0, 67, 266,
-		1, 0, 0, 0, 69, 276, 1, 0, 0, 0, 71, 281, 1, 0, 0, 0, 73, 285, 1, 0, 0,
-		0, 75, 305, 1, 0, 0, 0, 77, 307, 1, 0, 0, 0, 79, 309, 1, 0, 0, 0, 81, 311,
-		1, 0, 0, 0, 83, 317, 1, 0, 0, 0, 85, 319, 1, 0, 0, 0, 87, 326, 1, 0, 0,
-		0, 89, 90, 5, 112, 0, 0, 90, 91, 5, 114, 0, 0, 91, 2, 1, 0, 0, 0, 92, 93,
-		5, 91, 0, 0, 93, 94, 5, 102, 0, 0, 94, 95, 5, 54, 0, 0, 95, 96, 5, 52,
-		0, 0, 96, 97, 5, 93, 0, 0, 97, 4, 1, 0, 0, 0, 98, 99, 5, 91, 0, 0, 99,
-		100, 5, 105, 0, 0, 100, 101, 5, 54, 0, 0, 101, 102, 5, 52, 0, 0, 102, 103,
-		5, 93, 0, 0, 103, 6, 1, 0, 0, 0, 104, 105, 5, 91, 0, 0, 105, 106, 5, 117,
-		0, 0, 106, 107, 5, 105, 0, 0, 107, 108, 5, 54, 0, 0, 108, 109, 5, 52, 0,
-		0, 109, 110, 5, 93, 0, 0, 110, 8, 1, 0, 0, 0, 111, 112, 5, 91, 0, 0, 112,
-		113, 5, 105, 0, 0, 113, 114, 5, 93, 0, 0, 114, 10, 1, 0, 0, 0, 115, 116,
-		5, 91, 0, 0, 116, 117, 5, 117, 0, 0, 117, 118, 5, 105, 0, 0, 118, 119,
-		5, 93, 0, 0, 119, 12, 1, 0, 0, 0, 120, 121, 5, 91, 0, 0, 121, 122, 5, 105,
-		0, 0, 122, 123, 5, 51, 0, 0, 123, 124, 5, 50, 0, 0, 124, 125, 5, 93, 0,
-		0, 125, 14, 1, 0, 0, 0, 126, 127, 5, 91, 0, 0, 127, 128, 5, 117, 0, 0,
-		128, 129, 5, 105, 0, 0, 129, 130, 5, 51, 0, 0, 130, 131, 5, 50, 0, 0, 131,
-		132, 5, 93, 0, 0, 132, 16, 1, 0, 0, 0, 133, 134, 5, 91, 0, 0, 134, 135,
-		5, 100, 0, 0, 135, 136, 5, 93, 0, 0, 136, 18, 1, 0, 0, 0, 137, 138, 5,
-		91, 0, 0, 138, 139, 5, 115, 0, 0, 139, 140, 5, 93, 0, 0, 140, 20, 1, 0,
-		0, 0, 141, 142, 5, 91, 0, 0, 142, 143, 5, 102, 0, 0, 143, 144, 5, 51, 0,
-		0, 144, 145, 5, 50, 0, 0, 145, 146, 5, 93, 0, 0, 146, 22, 1, 0, 0, 0, 147,
-		148, 5, 46, 0, 0, 148, 24, 1, 0, 0, 0, 149, 150, 5, 45, 0, 0, 150, 26,
-		1, 0, 0, 0, 151, 152, 5, 91, 0, 0, 152, 28, 1, 0, 0, 0, 153, 154, 5, 93,
-		0, 0, 154, 30, 1, 0, 0, 0, 155, 156, 5, 110, 0, 0, 156, 157, 5, 111, 0,
-		0, 157, 162, 5, 116, 0, 0, 158, 159, 5, 78, 0, 0, 159, 160, 5, 79, 0, 0,
-		160, 162, 5, 84, 0, 0, 161, 155, 1, 0, 0, 0, 161, 158, 1, 0, 0, 0, 162,
-		32, 1, 0, 0, 0, 163, 164, 5, 97, 0, 0, 164, 165, 5, 110, 0, 0, 165, 169,
-		5, 100, 0, 0, 166, 167, 5, 111, 0, 0, 167, 169, 5, 114, 0, 0, 168, 163,
-		1, 0, 0, 0, 168, 166, 1, 0, 0, 0, 169, 34, 1, 0, 0, 0, 170, 171, 5, 116,
-		0, 0, 171, 172, 5, 114, 0, 0, 172, 173, 5, 117, 0, 0, 173, 180, 5, 101,
-		0, 0, 174, 175, 5, 102, 0, 0, 175, 176, 5, 97, 0, 0, 176, 177, 5, 108,
-		0, 0, 177, 178, 5, 115, 0, 0, 178, 180, 5, 101, 0, 0, 179, 170, 1, 0, 0,
-		0, 179, 174, 1, 0, 0, 0, 180, 36, 1, 0, 0, 0, 181, 182, 5, 110, 0, 0, 182,
-		183, 5, 117, 0, 0, 183, 184, 5, 108, 0, 0, 184, 189, 5, 108, 0, 0, 185,
-		186, 5, 110, 0, 0, 186, 187, 5, 105, 0, 0, 187, 189, 5, 108, 0, 0, 188,
-		181, 1, 0, 0, 0, 188, 185, 1, 0, 0, 0, 189, 38, 1, 0, 0, 0, 190, 191, 5,
-		73, 0, 0, 191, 195, 5, 78, 0, 0, 192, 193, 5, 105, 0, 0, 193, 195, 5, 110,
-		0, 0, 194, 190, 1, 0, 0, 0, 194, 192, 1, 0, 0, 0, 195, 40, 1, 0, 0, 0,
-		196, 197, 5, 101, 0, 0, 197, 201, 5, 113, 0, 0, 198, 199, 5, 69, 0, 0,
-		199, 201, 5, 81, 0, 0, 200, 196, 1, 0, 0, 0, 200, 198, 1, 0, 0, 0, 201,
-		42, 1, 0, 0, 0, 202, 203, 5, 110, 0, 0, 203, 207, 5, 101, 0, 0, 204, 205,
-		5, 78, 0, 0, 205, 207, 5, 69, 0, 0, 206, 202, 1, 0, 0, 0, 206, 204, 1,
-		0, 0, 0, 207, 44, 1, 0, 0, 0, 208, 209, 5, 103, 0, 0, 209, 213, 5, 116,
-		0, 0, 210, 211, 5, 71, 0, 0, 211, 213, 5, 84, 0, 0, 212, 208, 1, 0, 0,
-		0, 212, 210, 1, 0, 0, 0, 213, 46, 1, 0, 0, 0, 214, 215, 5, 108, 0, 0, 215,
-		219, 5, 116, 0, 0, 216, 217, 5, 76, 0, 0, 217, 219, 5, 84, 0, 0, 218, 214,
-		1, 0, 0, 0, 218, 216, 1, 0, 0, 0, 219, 48, 1, 0, 0, 0, 220, 221, 5, 103,
-		0, 0, 221, 225, 5, 101, 0, 0, 222, 223, 5, 71, 0, 0, 223, 225, 5, 69, 0,
-		0, 224, 220, 1, 0, 0, 0, 224, 222, 1, 0, 0, 0, 225, 50, 1, 0, 0, 0, 226,
-		227, 5, 108, 0, 0, 227, 231, 5, 101, 0, 0, 228, 229, 5, 76, 0, 0, 229,
-		231, 5, 69, 0, 0, 230, 226, 1, 0, 0, 0, 230, 228, 1, 0, 0, 0, 231, 52,
-		1, 0, 0, 0, 232, 233, 5, 99, 0, 0, 233, 237, 5, 111, 0, 0, 234, 235, 5,
-		67, 0, 0, 235, 237, 5, 79, 0, 0, 236, 232, 1, 0, 0, 0, 236, 234, 1, 0,
-		0, 0, 237, 54, 1, 0, 0, 0, 238, 239, 5, 115, 0, 0, 239, 243, 5, 119, 0,
-		0, 240, 241, 5, 83, 0, 0, 241, 243, 5, 87, 0, 0, 242, 238, 1, 0, 0, 0,
-		242, 240, 1, 0, 0, 0, 243, 56, 1, 0, 0, 0, 244, 245, 5, 101, 0, 0, 245,
-		249, 5, 119, 0, 0, 246, 247, 5, 69, 0, 0, 247, 249, 5, 87, 0, 0, 248, 244,
-		1, 0, 0, 0, 248, 246, 1, 0, 0, 0, 249, 58, 1, 0, 0, 0, 250, 254, 3, 65,
-		32, 0, 251, 253, 3, 61, 30, 0, 252, 251, 1, 0, 0, 0, 253, 256, 1, 0, 0,
-		0, 254, 252, 1, 0, 0, 0, 254, 255, 1, 0, 0, 0, 255, 60, 1, 0, 0, 0, 256,
-		254, 1, 0, 0, 0, 257, 261, 7, 0, 0, 0, 258, 261, 3, 63, 31, 0, 259, 261,
-		3, 65, 32, 0, 260, 257, 1, 0, 0, 0, 260, 258, 1, 0, 0, 0, 260, 259, 1,
-		0, 0, 0, 261, 62, 1, 0, 0, 0, 262, 263, 7, 1, 0, 0, 263, 64, 1, 0, 0, 0,
-		264, 265, 7, 2, 0, 0, 265, 66, 1, 0, 0, 0, 266, 271, 5, 34, 0, 0, 267,
-		270, 3, 69, 34, 0, 268, 270, 8, 3, 0, 0, 269, 267, 1, 0, 0, 0, 269, 268,
-		1, 0, 0, 0, 270, 273, 1, 0, 0, 0, 271, 269, 1, 0, 0, 0, 271, 272, 1, 0,
-		0, 0, 272, 274, 1, 0, 0, 0, 273, 271, 1, 0, 0, 0, 274, 275, 5, 34, 0, 0,
-		275, 68, 1, 0, 0, 0, 276, 279, 5, 92, 0, 0, 277, 280, 7, 4, 0, 0, 278,
-		280, 3, 71, 35, 0, 279, 277, 1, 0, 0, 0, 279, 278, 1, 0, 0, 0, 280, 70,
-		1, 0, 0, 0, 281, 282, 5, 117, 0, 0, 282, 283, 7, 5, 0, 0, 283, 72, 1, 0,
-		0, 0, 284, 286, 5, 45, 0, 0, 285, 284, 1, 0, 0, 0, 285, 286, 1, 0, 0, 0,
-		286, 287, 1, 0, 0, 0, 287, 288, 3, 75, 37, 0, 288, 290, 5, 46, 0, 0, 289,
-		291, 7, 1, 0, 0, 290, 289, 1, 0, 0, 0, 291, 292, 1, 0, 0, 0, 292, 290,
-		1, 0, 0, 0, 292, 293, 1, 0, 0, 0, 293, 295, 1, 0, 0, 0, 294, 296, 3, 81,
-		40, 0, 295, 294, 1, 0, 0, 0, 295, 296, 1, 0, 0, 0, 296, 74, 1, 0, 0, 0,
-		297, 306, 5, 48, 0, 0, 298, 302, 7, 6, 0, 0, 299, 301, 7, 1, 0, 0, 300,
-		299, 1, 0, 0, 0, 301, 304, 1, 0, 0, 0, 302, 300, 1, 0, 0, 0, 302, 303,
-		1, 0, 0, 0, 303, 306, 1, 0, 0, 0, 304, 302, 1, 0, 0, 0, 305, 297, 1, 0,
-		0, 0, 305, 298, 1, 0, 0, 0, 306, 76, 1, 0, 0, 0, 307, 308, 5, 40, 0, 0,
-		308, 78, 1, 0, 0, 0, 309, 310, 5, 41, 0, 0, 310, 80, 1, 0, 0, 0, 311, 313,
-		7, 7, 0, 0, 312, 314, 7, 8, 0, 0, 313, 312, 1, 0, 0, 0, 313, 314, 1, 0,
-		0, 0, 314, 315, 1, 0, 0, 0, 315, 316, 3, 75, 37, 0, 316, 82, 1, 0, 0, 0,
-		317, 318, 5, 10, 0, 0, 318, 84, 1, 0, 0, 0, 319, 323, 5, 44, 0, 0, 320,
-		322, 5, 32, 0, 0, 321, 320, 1, 0, 0, 0, 322, 325, 1, 0, 0, 0, 323, 321,
-		1, 0, 0, 0, 323, 324, 1, 0, 0, 0, 324, 86, 1, 0, 0, 0, 325, 323, 1, 0,
-		0, 0, 326, 330, 5, 32, 0, 0, 327, 329, 3, 83, 41, 0, 328, 327, 1, 0, 0,
-		0, 329, 332, 1, 0, 0, 0, 330, 328, 1, 0, 0, 0, 330, 331, 1, 0, 0, 0, 331,
-		88, 1, 0, 0, 0, 332, 330, 1, 0, 0, 0, 28, 0, 161, 168, 179, 188, 194, 200,
-		206, 212, 218, 224, 230, 236, 242, 248, 254, 260, 269, 271, 279, 285, 292,
-		295, 302, 305, 313, 323, 330, 0,
+		41, 2, 42, 7, 42, 2, 43, 7, 43, 2, 44, 7, 44, 2, 45, 7, 45, 2, 46, 7, 46,
+		2, 47, 7, 47, 2, 48, 7, 48, 2, 49, 7, 49, 2, 50, 7, 50, 2, 51, 7, 51, 2,
+		52, 7, 52, 2, 53, 7, 53, 2, 54, 7, 54, 2, 55, 7, 55, 2, 56, 7, 56, 2, 57,
+		7, 57, 2, 58, 7, 58, 2, 59, 7, 59, 2, 60, 7, 60, 2, 61, 7, 61, 2, 62, 7,
+		62, 2, 63, 7, 63, 2, 64, 7, 64, 2, 65, 7, 65, 2, 66, 7, 66, 2, 67, 7, 67,
+		2, 68, 7, 68, 2, 69, 7, 69, 2, 70, 7, 70, 2, 71, 7, 71, 2, 72, 7, 72, 2,
+		73, 7, 73, 2, 74, 7, 74, 2, 75, 7, 75, 2, 76, 7, 76, 2, 77, 7, 77, 2, 78,
+		7, 78, 2, 79, 7, 79, 1, 0, 1, 0, 1, 1, 1, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1,
+		2, 1, 2, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 4, 1, 4, 1, 4, 1, 4, 1,
+		4, 1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 1, 5, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1,
+		7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1,
+		8, 1, 9, 1, 9, 1, 9, 1, 9, 1, 10, 1, 10, 1, 10, 1, 10, 1, 11, 1, 11, 1,
+		11, 1, 11, 1, 11, 1, 11, 1, 12, 1, 12, 1, 12, 1, 12, 1, 13, 1, 13, 1, 13,
+		1, 13, 1, 13, 1, 13, 1, 13, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 1,
+		15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 1, 16,
+		1, 16, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1,
+		18, 1, 18, 1, 19, 1, 19, 1, 19, 1, 19, 1, 20, 1, 20, 1, 20, 1, 20, 1, 21,
+		1, 21, 1, 21, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1,
+		22, 3, 22, 281, 8, 22, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23,
+		3, 23, 290, 8, 23, 1, 24, 1, 24, 1, 24, 1, 25, 1, 25, 1, 25, 1, 25, 1,
+		26, 1, 26, 1, 26, 1, 27, 1, 27, 1, 27, 1, 28, 1, 28, 1, 28, 1, 29, 1, 29,
+		1, 29, 1, 30, 1, 30, 1, 30, 1, 31, 1, 31, 1, 31, 1, 32, 1, 32, 1, 32, 1,
+		33, 1, 33, 1, 33, 1, 34, 1, 34, 1, 34, 1, 35, 1, 35, 1, 35, 1, 35, 1, 35,
+		1, 35, 1, 35, 1, 35, 1, 35, 3, 35, 335, 8, 35, 1, 36, 1, 36, 1, 36, 1,
+		37, 1, 37, 1, 37, 1, 37, 1, 37, 1, 37, 1, 37, 1, 37, 1, 37, 1, 37, 3, 37,
+		350, 8, 37, 1, 38, 1, 38, 1, 38, 1, 38, 1, 39, 1, 39, 1, 39, 1, 39, 1,
+		40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 41, 1, 41, 1, 41, 1, 41, 1, 42, 1, 42,
+		5, 42, 371, 8, 42, 10, 42, 12, 42, 374, 9, 42, 1, 43, 1, 43, 1, 43, 3,
+		43, 379, 8, 43, 1, 44, 1, 44, 1, 45, 1, 45, 1, 46, 1, 46, 1, 47, 1, 47,
+		1, 48, 1, 48, 1, 49, 1, 49, 1, 50, 1, 50, 1, 51, 1, 51, 1, 52, 1, 52, 1,
+		53, 1, 53, 1, 54, 1, 54, 1, 55, 1, 55, 1, 56, 1, 56, 1, 57, 1, 57, 1, 58,
+		1, 58, 1, 59, 1, 59, 1, 60, 1, 60, 1, 61, 1, 61, 1, 62, 1, 62, 1, 63, 1,
+		63, 1, 63, 5, 63, 422, 8, 63, 10, 63, 12, 63, 425, 9, 63, 1, 63, 1, 63,
+		1, 63, 1, 63, 5, 63, 431, 8, 63, 10, 63, 12, 63, 434, 9, 63, 1, 63, 3,
+		63, 437, 8, 63, 1, 64, 1, 64, 1, 64, 1, 65, 1, 65, 1, 65, 4, 65, 445, 8,
+		65, 11, 65, 12, 65, 446, 1, 65, 3, 65, 450, 8, 65, 1, 66, 4, 66, 453, 8,
+		66, 11, 66, 12, 66, 454, 1, 67, 4, 67, 458, 8, 67, 11, 67, 12, 67, 459,
+		1, 67, 1, 67, 4, 67, 464, 8, 67, 11, 67, 12, 67, 465, 3, 67, 468, 8, 67,
+		1, 67, 1, 67, 1, 68, 1, 68, 1, 68, 1, 68, 1, 68, 1, 68, 1, 68, 3, 68, 479,
+		8, 68, 1, 69, 1, 69, 1, 69, 5, 69, 484, 8, 69, 10, 69, 12, 69, 487, 9,
+		69, 3, 69, 489, 8, 69, 1, 70, 1, 70, 1, 71, 1, 71, 1, 72, 1, 72, 1, 73,
+		1, 73, 1, 74, 1, 74, 1, 75, 1, 75, 1, 76, 1, 76, 1, 77, 1, 77, 3, 77, 507,
+		8, 77, 1, 77, 1, 77, 1, 78, 1, 78, 1, 79, 4, 79, 514, 8, 79, 11, 79, 12,
+		79, 515, 1, 79, 1, 79, 0, 0, 80, 1, 1, 3, 2, 5, 3, 7, 4, 9, 5, 11, 6, 13,
+		7, 15, 8, 17, 9, 19, 10, 21, 11, 23, 12, 25, 13, 27, 14, 29, 15, 31, 16,
+		33, 17, 35, 18, 37, 19, 39, 20, 41, 21, 43, 22, 45, 23, 47, 24, 49, 25,
+		51, 26, 53, 27, 55, 28, 57, 29, 59, 30, 61, 31, 63, 32, 65, 33, 67, 34,
+		69, 35, 71, 36, 73, 37, 75, 38, 77, 39, 79, 40, 81, 41, 83, 42, 85, 43,
+		87, 0, 89, 0, 91, 0, 93, 0, 95, 0, 97, 0, 99, 0, 101, 0, 103, 0, 105, 0,
+		107, 0, 109, 0, 111, 0, 113, 0, 115, 0, 117, 0, 119, 0, 121, 0, 123, 0,
+		125, 0, 127, 44, 129, 0, 131, 45, 133, 46, 135, 0, 137, 0, 139, 47, 141,
+		48, 143, 49, 145, 50, 147, 51, 149, 52, 151, 53, 153, 54, 155, 55, 157,
+		56, 159, 57, 1, 0, 26, 3, 0, 45, 45, 58, 58, 95, 95, 1, 0, 48, 57, 2, 0,
+		65, 90, 97, 122, 2, 0, 65, 65, 97, 97, 2, 0, 67, 67, 99, 99, 2, 0, 68,
+		68, 100, 100, 2, 0, 69, 69, 101, 101, 2, 0, 71, 71, 103, 103, 2, 0, 72,
+		72, 104, 104, 2, 0, 73, 73, 105, 105, 2, 0, 76, 76, 108, 108, 2, 0, 77,
+		77, 109, 109, 2, 0, 78, 78, 110, 110, 2, 0, 79, 79, 111, 111, 2, 0, 80,
+		80, 112, 112, 2, 0, 81, 81, 113, 113, 2, 0, 82, 82, 114, 114, 2, 0, 83,
+		83, 115, 115, 2, 0, 84, 84, 116, 116, 2, 0, 87, 87, 119, 119, 2, 0, 34,
+		34, 92, 92, 2, 0, 39, 39, 92, 92, 5, 0, 100, 100, 104, 104, 109, 109, 115,
+		115, 119, 119, 1, 0, 49, 57, 2, 0, 43, 43, 45, 45, 3, 0, 9, 10, 13, 13,
+		32, 32, 520, 0, 1, 1, 0, 0, 0, 0, 3, 1, 0, 0, 0, 0, 5, 1, 0, 0, 0, 0, 7,
+		1, 0, 0, 0, 0, 9, 1, 0, 0, 0, 0, 11, 1, 0, 0, 0, 0, 13, 1, 0, 0, 0, 0,
+		15, 1, 0, 0, 0, 0, 17, 1, 0, 0, 0, 0, 19, 1, 0, 0, 0, 0, 21, 1, 0, 0, 0,
+		0, 23, 1, 0, 0, 0, 0, 25, 1, 0, 0, 0, 0, 27, 1, 0, 0, 0, 0, 29, 1, 0, 0,
+		0, 0, 31, 1, 0, 0, 0, 0, 33, 1, 0, 0, 0, 0, 35, 1, 0, 0, 0, 0, 37, 1, 0,
+		0, 0, 0, 39, 1, 0, 0, 0, 0, 41, 1, 0, 0, 0, 0, 43, 1, 0, 0, 0, 0, 45, 1,
+		0, 0, 0, 0, 47, 1, 0, 0, 0, 0, 49, 1, 0, 0, 0, 0, 51, 1, 0, 0, 0, 0, 53,
+		1, 0, 0, 0, 0, 55, 1, 0, 0, 0, 0, 57, 1, 0, 0, 0, 0, 59, 1, 0, 0, 0, 0,
+		61, 1, 0, 0, 0, 0, 63, 1, 0, 0, 0, 0, 65, 1, 0, 0, 0, 0, 67, 1, 0, 0, 0,
+		0, 69, 1, 0, 0, 0, 0, 71, 1, 0, 0, 0, 0, 73, 1, 0, 0, 0, 0, 75, 1, 0, 0,
+		0, 0, 77, 1, 0, 0, 0, 0, 79, 1, 0, 0, 0, 0, 81, 1, 0, 0, 0, 0, 83, 1, 0,
+		0, 0, 0, 85, 1, 0, 0, 0, 0, 127, 1, 0, 0, 0, 0, 131, 1, 0, 0, 0, 0, 133,
+		1, 0, 0, 0, 0, 139, 1, 0, 0, 0, 0, 141, 1, 0, 0, 0, 0, 143, 1, 0, 0, 0,
+		0, 145, 1, 0, 0, 0, 0, 147, 1, 0, 0, 0, 0, 149, 1, 0, 0, 0, 0, 151, 1,
+		0, 0, 0, 0, 153, 1, 0, 0, 0, 0, 155, 1, 0, 0, 0, 0, 157, 1, 0, 0, 0, 0,
+		159, 1, 0, 0, 0, 1, 161, 1, 0, 0, 0, 3, 163, 1, 0, 0, 0, 5, 165, 1, 0,
+		0, 0, 7, 171, 1, 0, 0, 0, 9, 177, 1, 0, 0, 0, 11, 184, 1, 0, 0, 0, 13,
+		188, 1, 0, 0, 0, 15, 193, 1, 0, 0, 0, 17, 199, 1, 0, 0, 0, 19, 206, 1,
+		0, 0, 0, 21, 210, 1, 0, 0, 0, 23, 214, 1, 0, 0, 0, 25, 220, 1, 0, 0, 0,
+		27, 224, 1, 0, 0, 0, 29, 231, 1, 0, 0, 0, 31, 237, 1, 0, 0, 0, 33, 242,
+		1, 0, 0, 0, 35, 249, 1, 0, 0, 0, 37, 258, 1, 0, 0, 0, 39, 260, 1, 0, 0,
+		0, 41, 264, 1, 0, 0, 0, 43, 268, 1, 0, 0, 0, 45, 280, 1, 0, 0, 0, 47, 289,
+		1, 0, 0, 0, 49, 291, 1, 0, 0, 0, 51, 294, 1, 0, 0, 0, 53, 298, 1, 0, 0,
+		0, 55, 301, 1, 0, 0, 0, 57, 304, 1, 0, 0, 0, 59, 307, 1, 0, 0, 0, 61, 310,
+		1, 0, 0, 0, 63, 313, 1, 0, 0, 0, 65, 316, 1, 0, 0, 0, 67, 319, 1, 0, 0,
+		0, 69, 322, 1, 0, 0, 0, 71, 334, 1, 0, 0, 0, 73, 336, 1, 0, 0, 0, 75, 349,
+		1, 0, 0, 0, 77, 351, 1, 0, 0, 0, 79, 355, 1, 0, 0, 0, 81, 359, 1, 0, 0,
+		0, 83, 364, 1, 0, 0, 0, 85, 368, 1, 0, 0, 0, 87, 378, 1, 0, 0, 0, 89, 380,
+		1, 0, 0, 0, 91, 382, 1, 0, 0, 0, 93, 384, 1, 0, 0, 0, 95, 386, 1, 0, 0,
+		0, 97, 388, 1, 0, 0, 0, 99, 390, 1, 0, 0, 0, 101, 392, 1, 0, 0, 0, 103,
+		394, 1, 0, 0, 0, 105, 396, 1, 0, 0, 0, 107, 398, 1, 0, 0, 0, 109, 400,
+		1, 0, 0, 0, 111, 402, 1, 0, 0, 0, 113, 404, 1, 0, 0, 0, 115, 406, 1, 0,
+		0, 0, 117, 408, 1, 0, 0, 0, 119, 410, 1, 0, 0, 0, 121, 412, 1, 0, 0, 0,
+		123, 414, 1, 0, 0, 0, 125, 416, 1, 0, 0, 0, 127, 436, 1, 0, 0, 0, 129,
+		438, 1, 0, 0, 0, 131, 441, 1, 0, 0, 0, 133, 452, 1, 0, 0, 0, 135, 457,
+		1, 0, 0, 0, 137, 478, 1, 0, 0, 0, 139, 488, 1, 0, 0, 0, 141, 490, 1, 0,
+		0, 0, 143, 492, 1, 0, 0, 0, 145, 494, 1, 0, 0, 0, 147, 496, 1, 0, 0, 0,
+		149, 498, 1, 0, 0, 0, 151, 500, 1, 0, 0, 0, 153, 502, 1, 0, 0, 0, 155,
+		504, 1, 0, 0, 0, 157, 510, 1, 0, 0, 0, 159, 513, 1, 0, 0, 0, 161, 162,
+		5, 91, 0, 0, 162, 2, 1, 0, 0, 0, 163, 164, 5, 93, 0, 0, 164, 4, 1, 0, 0,
+		0, 165, 166, 5, 91, 0, 0, 166, 167, 5, 102, 0, 0, 167, 168, 5, 54, 0, 0,
+		168, 169, 5, 52, 0, 0, 169, 170, 5, 93, 0, 0, 170, 6, 1, 0, 0, 0, 171,
+		172, 5, 91, 0, 0, 172, 173, 5, 105, 0, 0, 173, 174, 5, 54, 0, 0, 174, 175,
+		5, 52, 0, 0, 175, 176, 5, 93, 0, 0, 176, 8, 1, 0, 0, 0, 177, 178, 5, 91,
+		0, 0, 178, 179, 5, 117, 0, 0, 179, 180, 5, 105, 0, 0, 180, 181, 5, 54,
+		0, 0, 181, 182, 5, 52, 0, 0, 182, 183, 5, 93, 0, 0, 183, 10, 1, 0, 0, 0,
+		184, 185, 5, 91, 0, 0, 185, 186, 5, 105, 0, 0, 186, 187, 5, 93, 0, 0, 187,
+		12, 1, 0, 0, 0, 188, 189, 5, 91, 0, 0, 189, 190, 5, 117, 0, 0, 190, 191,
+		5, 105, 0, 0, 191, 192, 5, 93, 0, 0, 192, 14, 1, 0, 0, 0, 193, 194, 5,
+		91, 0, 0, 194, 195, 5, 105, 0, 0, 195, 196, 5, 51, 0, 0, 196, 197, 5, 50,
+		0, 0, 197, 198, 5, 93, 0, 0, 198, 16, 1, 0, 0, 0, 199, 200, 5, 91, 0, 0,
+		200, 201, 5, 117, 0, 0, 201, 202, 5, 105, 0, 0, 202, 203, 5, 51, 0, 0,
+		203, 204, 5, 50, 0, 0, 204, 205, 5, 93, 0, 0, 205, 18, 1, 0, 0, 0, 206,
+		207, 5, 91, 0, 0, 207, 208, 5, 100, 0, 0, 208, 209, 5, 93, 0, 0, 209, 20,
+		1, 0, 0, 0, 210, 211, 5, 91, 0, 0, 211, 212, 5, 115, 0, 0, 212, 213, 5,
+		93, 0, 0, 213, 22, 1, 0, 0, 0, 214, 215, 5, 91, 0, 0, 215, 216, 5, 102,
+		0, 0, 216, 217, 5, 51, 0, 0, 217, 218, 5, 50, 0, 0, 218, 219, 5, 93, 0,
+		0, 219, 24, 1, 0, 0, 0, 220, 221, 5, 91, 0, 0, 221, 222, 5, 116, 0, 0,
+		222, 223, 5, 93, 0, 0, 223, 26, 1, 0, 0, 0, 224, 225, 5, 91, 0, 0, 225,
+		226, 5, 100, 0, 0, 226, 227, 5, 97, 0, 0, 227, 228, 5, 116, 0, 0, 228,
+		229, 5, 101, 0, 0, 229, 230, 5, 93, 0, 0, 230, 28, 1, 0, 0, 0, 231, 232,
+		5, 91, 0, 0, 232, 233, 5, 100, 0, 0, 233, 234, 5, 117, 0, 0, 234, 235,
+		5, 114, 0, 0, 235, 236, 5, 93, 0, 0, 236, 30, 1, 0, 0, 0, 237, 238, 5,
+		91, 0, 0, 238, 239, 5, 105, 0, 0, 239, 240, 5, 112, 0, 0, 240, 241, 5,
+		93, 0, 0, 241, 32, 1, 0, 0, 0, 242, 243, 5, 91, 0, 0, 243, 244, 5, 99,
+		0, 0, 244, 245, 5, 105, 0, 0, 245, 246, 5, 100, 0, 0, 246, 247, 5, 114,
+		0, 0, 247, 248, 5, 93, 0, 0, 248, 34, 1, 0, 0, 0, 249, 250, 5, 91, 0, 0,
+		250, 251, 5, 115, 0, 0, 251, 252, 5, 101, 0, 0, 252, 253, 5, 109, 0, 0,
+		253, 254, 5, 118, 0, 0, 254, 255, 5, 101, 0, 0, 255, 256, 5, 114, 0, 0,
+		256, 257, 5, 93, 0, 0, 257, 36, 1, 0, 0, 0, 258, 259, 5, 46, 0, 0, 259,
+		38, 1, 0, 0, 0, 260, 261, 3, 111, 55, 0, 261, 262, 3, 113, 56, 0, 262,
+		263, 3, 123, 61, 0, 263, 40, 1, 0, 0, 0, 264, 265, 3, 93, 46, 0, 265, 266,
+		3, 111, 55, 0, 266, 267, 3, 97, 48, 0, 267, 42, 1, 0, 0, 0, 268, 269, 3,
+		113, 56, 0, 269, 270, 3, 119, 59, 0, 270, 44, 1, 0, 0, 0, 271, 272, 5,
+		116, 0, 0, 272, 273, 5, 114, 0, 0, 273, 274, 5, 117, 0, 0, 274, 281, 5,
+		101, 0, 0, 275, 276, 5, 102, 0, 0, 276, 277, 5, 97, 0, 0, 277, 278, 5,
+		108, 0, 0, 278, 279, 5, 115, 0, 0, 279, 281, 5, 101, 0, 0, 280, 271, 1,
+		0, 0, 0, 280, 275, 1, 0, 0, 0, 281, 46, 1, 0, 0, 0, 282, 283, 5, 110, 0,
+		0, 283, 284, 5, 117, 0, 0, 284, 285, 5, 108, 0, 0, 285, 290, 5, 108, 0,
+		0, 286, 287, 5, 110, 0, 0, 287, 288, 5, 105, 0, 0, 288, 290, 5, 108, 0,
+		0, 289, 282, 1, 0, 0, 0, 289, 286, 1, 0, 0, 0, 290, 48, 1, 0, 0, 0, 291,
+		292, 3, 105, 52, 0, 292, 293, 3, 111, 55, 0, 293, 50, 1, 0, 0, 0, 294,
+		295, 3, 111, 55, 0, 295, 296, 3, 105, 52, 0, 296, 297, 3, 111, 55, 0, 297,
+		52, 1, 0, 0, 0, 298, 299, 3, 99, 49, 0, 299, 300, 3, 117, 58, 0, 300, 54,
+		1, 0, 0, 0, 301, 302, 3, 111, 55, 0, 302, 303, 3, 99, 49, 0, 303, 56, 1,
+		0, 0, 0, 304, 305, 3, 101, 50, 0, 305, 306, 3, 123, 61, 0, 306, 58, 1,
+		0, 0, 0, 307, 308, 3, 107, 53, 0, 308, 309, 3, 123, 61, 0, 309, 60, 1,
+		0, 0, 0, 310, 311, 3, 101, 50, 0, 311, 312, 3, 99, 49, 0, 312, 62, 1, 0,
+		0, 0, 313, 314, 3, 107, 53, 0, 314, 315, 3, 99, 49, 0, 315, 64, 1, 0, 0,
+		0, 316, 317, 3, 95, 47, 0, 317, 318, 3, 113, 56, 0, 318, 66, 1, 0, 0, 0,
+		319, 320, 3, 121, 60, 0, 320, 321, 3, 125, 62, 0, 321, 68, 1, 0, 0, 0,
+		322, 323, 3, 99, 49, 0, 323, 324, 3, 125, 62, 0, 324, 70, 1, 0, 0, 0, 325,
+		326, 3, 109, 54, 0, 326, 327, 3, 119, 59, 0, 327, 335, 1, 0, 0, 0, 328,
+		329, 3, 109, 54, 0, 329, 330, 3, 93, 46, 0, 330, 331, 3, 123, 61, 0, 331,
+		332, 3, 95, 47, 0, 332, 333, 3, 103, 51, 0, 333, 335, 1, 0, 0, 0, 334,
+		325, 1, 0, 0, 0, 334, 328, 1, 0, 0, 0, 335, 72, 1, 0, 0, 0, 336, 337, 3,
+		115, 57, 0, 337, 338, 3, 119, 59, 0, 338, 74, 1, 0, 0, 0, 339, 340, 3,
+		125, 62, 0, 340, 341, 3, 105, 52, 0, 341, 350, 1, 0, 0, 0, 342, 343, 3,
+		125, 62, 0, 343, 344, 3, 105, 52, 0, 344, 345, 3, 123, 61, 0, 345, 346,
+		3, 103, 51, 0, 346, 347, 3, 105, 52, 0, 347, 348, 3, 111, 55, 0, 348, 350,
+		1, 0, 0, 0, 349, 339, 1, 0, 0, 0, 349, 342, 1, 0, 0, 0, 350, 76, 1, 0,
+		0, 0, 351, 352, 5, 97, 0, 0, 352, 353, 5, 110, 0, 0, 353, 354, 5, 121,
+		0, 0, 354, 78, 1, 0, 0, 0, 355, 356, 5, 97, 0, 0, 356, 357, 5, 108, 0,
+		0, 357, 358, 5, 108, 0, 0, 358, 80, 1, 0, 0, 0, 359, 360, 5, 110, 0, 0,
+		360, 361, 5, 111, 0, 0, 361, 362, 5, 110, 0, 0, 362, 363, 5, 101, 0, 0,
+		363, 82, 1, 0, 0, 0, 364, 365, 5, 108, 0, 0, 365, 366, 5, 101, 0, 0, 366,
+		367, 5, 110, 0, 0, 367, 84, 1, 0, 0, 0, 368, 372, 3, 91, 45, 0, 369, 371,
+		3, 87, 43, 0, 370, 369, 1, 0, 0, 0, 371, 374, 1, 0, 0, 0, 372, 370, 1,
+		0, 0, 0, 372, 373, 1, 0, 0, 0, 373, 86, 1, 0, 0, 0, 374, 372, 1, 0, 0,
+		0, 375, 379, 7, 0, 0, 0, 376, 379, 3, 89, 44, 0, 377, 379, 3, 91, 45, 0,
+		378, 375, 1, 0, 0, 0, 378, 376, 1, 0, 0, 0, 378, 377, 1, 0, 0, 0, 379,
+		88, 1, 0, 0, 0, 380, 381, 7, 1, 0, 0, 381, 90, 1, 0, 0, 0, 382, 383, 7,
+		2, 0, 0, 383, 92, 1, 0, 0, 0, 384, 385, 7, 3, 0, 0, 385, 94, 1, 0, 0, 0,
+		386, 387, 7, 4, 0, 0, 387, 96, 1, 0, 0, 0, 388, 389, 7, 5, 0, 0, 389, 98,
+		1, 0, 0, 0, 390, 391, 7, 6, 0, 0, 391, 100, 1, 0, 0, 0, 392, 393, 7, 7,
+		0, 0, 393, 102, 1, 0, 0, 0, 394, 395, 7, 8, 0, 0, 395, 104, 1, 0, 0, 0,
+		396, 397, 7, 9, 0, 0, 397, 106, 1, 0, 0, 0, 398, 399, 7, 10, 0, 0, 399,
+		108, 1, 0, 0, 0, 400, 401, 7, 11, 0, 0, 401, 110, 1, 0, 0, 0, 402, 403,
+		7, 12, 0, 0, 403, 112, 1, 0, 0, 0, 404, 405, 7, 13, 0, 0, 405, 114, 1,
+		0, 0, 0, 406, 407, 7, 14, 0, 0, 407, 116, 1, 0, 0, 0, 408, 409, 7, 15,
+		0, 0, 409, 118, 1, 0, 0, 0, 410, 411, 7, 16, 0, 0, 411, 120, 1, 0, 0, 0,
+		412, 413, 7, 17, 0, 0, 413, 122, 1, 0, 0, 0, 414, 415, 7, 18, 0, 0, 415,
+		124, 1, 0, 0, 0, 416, 417, 7, 19, 0, 0, 417, 126, 1, 0, 0, 0, 418, 423,
+		5, 34, 0, 0, 419, 422, 3, 129, 64, 0, 420, 422, 8, 20, 0, 0, 421, 419,
+		1, 0, 0, 0, 421, 420, 1, 0, 0, 0, 422, 425, 1, 0, 0, 0, 423, 421, 1, 0,
+		0, 0, 423, 424, 1, 0, 0, 0, 424, 426, 1, 0, 0, 0, 425, 423, 1, 0, 0, 0,
+		426, 437, 5, 34, 0, 0, 427, 432, 5, 39, 0, 0, 428, 431, 3, 129, 64, 0,
+		429, 431, 8, 21, 0, 0, 430, 428, 1, 0, 0, 0, 430, 429, 1, 0, 0, 0, 431,
+		434, 1, 0, 0, 0, 432, 430, 1, 0, 0, 0, 432, 433, 1, 0, 0, 0, 433, 435,
+		1, 0, 0, 0, 434, 432, 1, 0, 0, 0, 435, 437, 5, 39, 0, 0, 436, 418, 1, 0,
+		0, 0, 436, 427, 1, 0, 0, 0, 437, 128, 1, 0, 0, 0, 438, 439, 5, 92, 0, 0,
+		439, 440, 9, 0, 0, 0, 440, 130, 1, 0, 0, 0, 441, 442, 3, 139, 69, 0, 442,
+		444, 5, 46, 0, 0, 443, 445, 7, 1, 0, 0, 444, 443, 1, 0, 0, 0, 445, 446,
+		1, 0, 0, 0, 446, 444, 1, 0, 0, 0, 446, 447, 1, 0, 0, 0, 447, 449, 1, 0,
+		0, 0, 448, 450, 3, 155, 77, 0, 449, 448, 1, 0, 0, 0, 449, 450, 1, 0, 0,
+		0, 450, 132, 1, 0, 0, 0, 451, 453, 3, 135, 67, 0, 452, 451, 1, 0, 0, 0,
+		453, 454, 1, 0, 0, 0, 454, 452, 1, 0, 0, 0, 454, 455, 1, 0, 0, 0, 455,
+		134, 1, 0, 0, 0, 456, 458, 7, 1, 0, 0, 457, 456, 1, 0, 0, 0, 458, 459,
+		1, 0, 0, 0, 459, 457, 1, 0, 0, 0, 459, 460, 1, 0, 0, 0, 460, 467, 1, 0,
+		0, 0, 461, 463, 5, 46, 0, 0, 462, 464, 7, 1, 0, 0, 463, 462, 1, 0, 0, 0,
+		464, 465, 1, 0, 0, 0, 465, 463, 1, 0, 0, 0, 465, 466, 1, 0, 0, 0, 466,
+		468, 1, 0, 0, 0, 467, 461, 1, 0, 0, 0, 467, 468, 1, 0, 0, 0, 468, 469,
+		1, 0, 0, 0, 469, 470, 3, 137, 68, 0, 470, 136, 1, 0, 0, 0, 471, 472, 5,
+		110, 0, 0, 472, 479, 5, 115, 0, 0, 473, 474, 5, 117, 0, 0, 474, 479, 5,
+		115, 0, 0, 475, 476, 5, 109, 0, 0, 476, 479, 5, 115, 0, 0, 477, 479, 7,
+		22, 0, 0, 478, 471, 1, 0, 0, 0, 478, 473, 1, 0, 0, 0, 478, 475, 1, 0, 0,
+		0, 478, 477, 1, 0, 0, 0, 479, 138, 1, 0, 0, 0, 480, 489, 5, 48, 0, 0, 481,
+		485, 7, 23, 0, 0, 482, 484, 7, 1, 0, 0, 483, 482, 1, 0, 0, 0, 484, 487,
+		1, 0, 0, 0, 485, 483, 1, 0, 0, 0, 485, 486, 1, 0, 0, 0, 486, 489, 1, 0,
+		0, 0, 487, 485, 1, 0, 0, 0, 488, 480, 1, 0, 0, 0, 488, 481, 1, 0, 0, 0,
+		489, 140, 1, 0, 0, 0, 490, 491, 5, 42, 0, 0, 491, 142, 1, 0, 0, 0, 492,
+		493, 5, 47, 0, 0, 493, 144, 1, 0, 0, 0, 494, 495, 5, 37, 0, 0, 495, 146,
+		1, 0, 0, 0, 496, 497, 5, 43, 0, 0, 497, 148, 1, 0, 0, 0, 498, 499, 5, 45,
+		0, 0, 499, 150, 1, 0, 0, 0, 500, 501, 5, 40, 0, 0, 501, 152, 1, 0, 0, 0,
+		502, 503, 5, 41, 0, 0, 503, 154, 1, 0, 0, 0, 504, 506, 7, 6, 0, 0, 505,
+		507, 7, 24, 0, 0, 506, 505, 1, 0, 0, 0, 506, 507, 1, 0, 0, 0, 507, 508,
+		1, 0, 0, 0, 508, 509, 3, 139, 69, 0, 509, 156, 1, 0, 0, 0, 510, 511, 5,
+		44, 0, 0, 511, 158, 1, 0, 0, 0, 512, 514, 7, 25, 0, 0, 513, 512, 1, 0,
+		0, 0, 514, 515, 1, 0, 0, 0, 515, 513, 1, 0, 0, 0, 515, 516, 1, 0, 0, 0,
+		516, 517, 1, 0, 0, 0, 517, 518, 6, 79, 0, 0, 518, 160, 1, 0, 0, 0, 23,
+		0, 280, 289, 334, 349, 372, 378, 421, 423, 430, 432, 436, 446, 449, 454,
+		459, 465, 467, 478, 485, 488, 506, 515, 1, 6, 0, 0,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -256,43 +342,61 @@ func NewSCIMQueryLexer(input antlr.CharStream) *SCIMQueryLexer {
 
 // SCIMQueryLexer tokens.
 const (
-	SCIMQueryLexerT__0             = 1
-	SCIMQueryLexerT__1             = 2
-	SCIMQueryLexerT__2             = 3
-	SCIMQueryLexerT__3             = 4
-	SCIMQueryLexerT__4             = 5
-	SCIMQueryLexerT__5             = 6
-	SCIMQueryLexerT__6             = 7
-	SCIMQueryLexerT__7             = 8
-	SCIMQueryLexerT__8             = 9
-	SCIMQueryLexerT__9             = 10
-	SCIMQueryLexerT__10            = 11
-	SCIMQueryLexerT__11            = 12
-	SCIMQueryLexerT__12            = 13
-	SCIMQueryLexerT__13            = 14
-	SCIMQueryLexerT__14            = 15
-	SCIMQueryLexerNOT              = 16
-	SCIMQueryLexerLOGICAL_OPERATOR = 17
-	SCIMQueryLexerBOOLEAN          = 18
-	SCIMQueryLexerNULL             = 19
-	SCIMQueryLexerIN               = 20
-	SCIMQueryLexerEQ               = 21
-	SCIMQueryLexerNE               = 22
-	SCIMQueryLexerGT               = 23
-	SCIMQueryLexerLT               = 24
-	SCIMQueryLexerGE               = 25
-	SCIMQueryLexerLE               = 26
-	SCIMQueryLexerCO               = 27
-	SCIMQueryLexerSW               = 28
-	SCIMQueryLexerEW               = 29
-	SCIMQueryLexerATTRNAME         = 30
-	SCIMQueryLexerSTRING           = 31
-	SCIMQueryLexerDOUBLE           = 32
-	SCIMQueryLexerINT              = 33
-	SCIMQueryLexerLPAREN           = 34
-	SCIMQueryLexerRPAREN           = 35
-	SCIMQueryLexerEXP              = 36
-	SCIMQueryLexerNEWLINE          = 37
-	SCIMQueryLexerCOMMA            = 38
-	SCIMQueryLexerSP               = 39
+	SCIMQueryLexerT__0     = 1
+	SCIMQueryLexerT__1     = 2
+	SCIMQueryLexerT__2     = 3
+	SCIMQueryLexerT__3     = 4
+	SCIMQueryLexerT__4     = 5
+	SCIMQueryLexerT__5     = 6
+	SCIMQueryLexerT__6     = 7
+	SCIMQueryLexerT__7     = 8
+	SCIMQueryLexerT__8     = 9
+	SCIMQueryLexerT__9     = 10
+	SCIMQueryLexerT__10    = 11
+	SCIMQueryLexerT__11    = 12
+	SCIMQueryLexerT__12    = 13
+	SCIMQueryLexerT__13    = 14
+	SCIMQueryLexerT__14    = 15
+	SCIMQueryLexerT__15    = 16
+	SCIMQueryLexerT__16    = 17
+	SCIMQueryLexerT__17    = 18
+	SCIMQueryLexerT__18    = 19
+	SCIMQueryLexerNOT      = 20
+	SCIMQueryLexerAND      = 21
+	SCIMQueryLexerOR       = 22
+	SCIMQueryLexerBOOLEAN  = 23
+	SCIMQueryLexerNULL     = 24
+	SCIMQueryLexerIN       = 25
+	SCIMQueryLexerNIN      = 26
+	SCIMQueryLexerEQ       = 27
+	SCIMQueryLexerNE       = 28
+	SCIMQueryLexerGT       = 29
+	SCIMQueryLexerLT       = 30
+	SCIMQueryLexerGE       = 31
+	SCIMQueryLexerLE       = 32
+	SCIMQueryLexerCO       = 33
+	SCIMQueryLexerSW       = 34
+	SCIMQueryLexerEW       = 35
+	SCIMQueryLexerMR       = 36
+	SCIMQueryLexerPR       = 37
+	SCIMQueryLexerWI       = 38
+	SCIMQueryLexerANY      = 39
+	SCIMQueryLexerALL      = 40
+	SCIMQueryLexerNONE     = 41
+	SCIMQueryLexerLEN      = 42
+	SCIMQueryLexerATTRNAME = 43
+	SCIMQueryLexerSTRING   = 44
+	SCIMQueryLexerDOUBLE   = 45
+	SCIMQueryLexerDURATION = 46
+	SCIMQueryLexerINT      = 47
+	SCIMQueryLexerSTAR     = 48
+	SCIMQueryLexerSLASH    = 49
+	SCIMQueryLexerPERCENT  = 50
+	SCIMQueryLexerPLUS     = 51
+	SCIMQueryLexerMINUS    = 52
+	SCIMQueryLexerLPAREN   = 53
+	SCIMQueryLexerRPAREN   = 54
+	SCIMQueryLexerEXP      = 55
+	SCIMQueryLexerCOMMA    = 56
+	SCIMQueryLexerWS       = 57
 )
@@ -20,12 +20,18 @@ type SCIMQueryListener interface {
 	// EnterPresentExp is called when entering the presentExp production.
 	EnterPresentExp(c *PresentExpContext)
 
+	// EnterValuePathExp is called when entering the valuePathExp production.
+	EnterValuePathExp(c *ValuePathExpContext)
+
 	// EnterLogicalExp is called when entering the logicalExp production.
 	EnterLogicalExp(c *LogicalExpContext)
 
 	// EnterAttrPath is called when entering the attrPath production.
 	EnterAttrPath(c *AttrPathContext)
 
+	// EnterAttrName is called when entering the attrName production.
+	EnterAttrName(c *AttrNameContext)
+
 	// EnterTypeAnnotation is called when entering the typeAnnotation production.
 	EnterTypeAnnotation(c *TypeAnnotationContext)
 
@@ -50,20 +56,38 @@ type SCIMQueryListener interface {
 	// EnterTypedVal is called when entering the typedVal production.
 	EnterTypedVal(c *TypedValContext)
 
+	// EnterListOfDoubles is called when entering the listOfDoubles production.
+	EnterListOfDoubles(c *ListOfDoublesContext)
+
+	// EnterListOfStrings is called when entering the listOfStrings production.
+	EnterListOfStrings(c *ListOfStringsContext)
+
+	// EnterLenVal is called when entering the lenVal production.
+	EnterLenVal(c *LenValContext)
+
 	// EnterBoolean is called when entering the boolean production.
 	EnterBoolean(c *BooleanContext)
 
 	// EnterNull is called when entering the null production.
 	EnterNull(c *NullContext)
 
-	// EnterListOfInts is called when entering the listOfInts production.
-	EnterListOfInts(c *ListOfIntsContext)
+	// EnterAttrVal is called when entering the attrVal production.
+	EnterAttrVal(c *AttrValContext)
 
-	// EnterListOfDoubles is called when entering the listOfDoubles production.
-	EnterListOfDoubles(c *ListOfDoublesContext)
+	// EnterArithmeticVal is called when entering the arithmeticVal production.
+	EnterArithmeticVal(c *ArithmeticValContext)
 
-	// EnterListOfStrings is called when entering the listOfStrings production.
-	EnterListOfStrings(c *ListOfStringsContext)
+	// EnterDurationVal is called when entering the durationVal production.
+	EnterDurationVal(c *DurationValContext)
+
+	// EnterQuantifierVal is called when entering the quantifierVal production.
+	EnterQuantifierVal(c *QuantifierValContext)
+
+	// EnterParenVal is called when entering the parenVal production.
+	EnterParenVal(c *ParenValContext)
+
+	// EnterListOfInts is called when entering the listOfInts production.
+	EnterListOfInts(c *ListOfIntsContext)
 
 	// EnterListStrings is called when entering the listStrings production.
 	EnterListStrings(c *ListStringsContext)
@@ -95,12 +119,18 @@ type SCIMQueryListener interface {
 	// ExitPresentExp is called when exiting the presentExp production.
 	ExitPresentExp(c *PresentExpContext)
 
+	// ExitValuePathExp is called when exiting the valuePathExp production.
+	ExitValuePathExp(c *ValuePathExpContext)
+
 	// ExitLogicalExp is called when exiting the logicalExp production.
 	ExitLogicalExp(c *LogicalExpContext)
 
 	// ExitAttrPath is called when exiting the attrPath production.
 	ExitAttrPath(c *AttrPathContext)
 
+	// ExitAttrName is called when exiting the attrName production.
+	ExitAttrName(c *AttrNameContext)
+
 	// ExitTypeAnnotation is called when exiting the typeAnnotation production.
 	ExitTypeAnnotation(c *TypeAnnotationContext)
 
@@ -125,20 +155,38 @@ type SCIMQueryListener interface {
 	// ExitTypedVal is called when exiting the typedVal production.
 	ExitTypedVal(c *TypedValContext)
 
+	// ExitListOfDoubles is called when exiting the listOfDoubles production.
+	ExitListOfDoubles(c *ListOfDoublesContext)
+
+	// ExitListOfStrings is called when exiting the listOfStrings production.
+	ExitListOfStrings(c *ListOfStringsContext)
+
+	// ExitLenVal is called when exiting the lenVal production.
+	ExitLenVal(c *LenValContext)
+
 	// ExitBoolean is called when exiting the boolean production.
 	ExitBoolean(c *BooleanContext)
 
 	// ExitNull is called when exiting the null production.
 	ExitNull(c *NullContext)
 
-	// ExitListOfInts is called when exiting the listOfInts production.
-	ExitListOfInts(c *ListOfIntsContext)
+	// ExitAttrVal is called when exiting the attrVal production.
+	ExitAttrVal(c *AttrValContext)
 
-	// ExitListOfDoubles is called when exiting the listOfDoubles production.
-	ExitListOfDoubles(c *ListOfDoublesContext)
+	// ExitArithmeticVal is called when exiting the arithmeticVal production.
+	ExitArithmeticVal(c *ArithmeticValContext)
 
-	// ExitListOfStrings is called when exiting the listOfStrings production.
-	ExitListOfStrings(c *ListOfStringsContext)
+	// ExitDurationVal is called when exiting the durationVal production.
+	ExitDurationVal(c *DurationValContext)
+
+	// ExitQuantifierVal is called when exiting the quantifierVal production.
+	ExitQuantifierVal(c *QuantifierValContext)
+
+	// ExitParenVal is called when exiting the parenVal production.
+	ExitParenVal(c *ParenValContext)
+
+	// ExitListOfInts is called when exiting the listOfInts production.
+	ExitListOfInts(c *ListOfIntsContext)
 
 	// ExitListStrings is called when exiting the listStrings production.
 	ExitListStrings(c *ListStringsContext)
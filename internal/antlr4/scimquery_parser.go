@@ -33,93 +33,126 @@ var SCIMQueryParserStaticData struct {
 func scimqueryParserInit() {
 	staticData := &SCIMQueryParserStaticData
 	staticData.LiteralNames = []string{
-		"", "'pr'", "'[f64]'", "'[i64]'", "'[ui64]'", "'[i]'", "'[ui]'", "'[i32]'",
-		"'[ui32]'", "'[d]'", "'[s]'", "'[f32]'", "'.'", "'-'", "'['", "']'",
-		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
-		"", "'('", "')'", "", "'\\n'",
+		"", "'['", "']'", "'[f64]'", "'[i64]'", "'[ui64]'", "'[i]'", "'[ui]'",
+		"'[i32]'", "'[ui32]'", "'[d]'", "'[s]'", "'[f32]'", "'[t]'", "'[date]'",
+		"'[dur]'", "'[ip]'", "'[cidr]'", "'[semver]'", "'.'", "", "", "", "",
+		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "'any'",
+		"'all'", "'none'", "'len'", "", "", "", "", "", "'*'", "'/'", "'%'",
+		"'+'", "'-'", "'('", "')'", "", "','",
 	}
 	staticData.SymbolicNames = []string{
-		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "NOT",
-		"LOGICAL_OPERATOR", "BOOLEAN", "NULL", "IN", "EQ", "NE", "GT", "LT",
-		"GE", "LE", "CO", "SW", "EW", "ATTRNAME", "STRING", "DOUBLE", "INT",
-		"LPAREN", "RPAREN", "EXP", "NEWLINE", "COMMA", "SP",
+		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
+		"", "", "", "NOT", "AND", "OR", "BOOLEAN", "NULL", "IN", "NIN", "EQ",
+		"NE", "GT", "LT", "GE", "LE", "CO", "SW", "EW", "MR", "PR", "WI", "ANY",
+		"ALL", "NONE", "LEN", "ATTRNAME", "STRING", "DOUBLE", "DURATION", "INT",
+		"STAR", "SLASH", "PERCENT", "PLUS", "MINUS", "LPAREN", "RPAREN", "EXP",
+		"COMMA", "WS",
 	}
 	staticData.RuleNames = []string{
-		"root", "query", "attrPath", "typeAnnotation", "functionCall", "argList",
-		"subAttr", "typedValue", "value", "listStrings", "subListOfStrings",
+		"root", "query", "attrPath", "attrName", "typeAnnotation", "functionCall",
+		"argList", "subAttr", "typedValue", "value", "listStrings", "subListOfStrings",
 		"listDoubles", "subListOfDoubles", "listInts", "subListOfInts",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 39, 161, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 57, 217, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
 		4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10, 7,
-		10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 1, 0, 1, 0,
-		1, 0, 1, 1, 1, 1, 3, 1, 36, 8, 1, 1, 1, 3, 1, 39, 8, 1, 1, 1, 1, 1, 3,
-		1, 43, 8, 1, 1, 1, 1, 1, 3, 1, 47, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-		1, 1, 1, 1, 1, 1, 3, 1, 57, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 64,
-		8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 5, 1, 71, 8, 1, 10, 1, 12, 1, 74, 9,
-		1, 1, 2, 1, 2, 3, 2, 78, 8, 2, 1, 2, 3, 2, 81, 8, 2, 1, 3, 1, 3, 1, 4,
-		1, 4, 1, 4, 3, 4, 88, 8, 4, 1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 5, 5, 95, 8,
-		5, 10, 5, 12, 5, 98, 9, 5, 1, 6, 1, 6, 1, 6, 1, 7, 3, 7, 104, 8, 7, 1,
-		7, 1, 7, 3, 7, 108, 8, 7, 1, 7, 1, 7, 3, 7, 112, 8, 7, 1, 7, 3, 7, 115,
-		8, 7, 1, 7, 1, 7, 3, 7, 119, 8, 7, 3, 7, 121, 8, 7, 1, 8, 1, 8, 1, 8, 1,
-		8, 1, 8, 1, 8, 3, 8, 129, 8, 8, 1, 9, 1, 9, 1, 9, 1, 10, 1, 10, 1, 10,
-		1, 10, 1, 10, 3, 10, 139, 8, 10, 1, 11, 1, 11, 1, 11, 1, 12, 1, 12, 1,
-		12, 1, 12, 1, 12, 3, 12, 149, 8, 12, 1, 13, 1, 13, 1, 13, 1, 14, 1, 14,
-		1, 14, 1, 14, 1, 14, 3, 14, 159, 8, 14, 1, 14, 0, 1, 2, 15, 0, 2, 4, 6,
-		8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 0, 2, 1, 0, 20, 29, 1, 0, 2,
-		11, 172, 0, 30, 1, 0, 0, 0, 2, 63, 1, 0, 0, 0, 4, 80, 1, 0, 0, 0, 6, 82,
-		1, 0, 0, 0, 8, 84, 1, 0, 0, 0, 10, 91, 1, 0, 0, 0, 12, 99, 1, 0, 0, 0,
-		14, 120, 1, 0, 0, 0, 16, 128, 1, 0, 0, 0, 18, 130, 1, 0, 0, 0, 20, 138,
-		1, 0, 0, 0, 22, 140, 1, 0, 0, 0, 24, 148, 1, 0, 0, 0, 26, 150, 1, 0, 0,
-		0, 28, 158, 1, 0, 0, 0, 30, 31, 3, 2, 1, 0, 31, 32, 5, 0, 0, 1, 32, 1,
-		1, 0, 0, 0, 33, 35, 6, 1, -1, 0, 34, 36, 5, 16, 0, 0, 35, 34, 1, 0, 0,
-		0, 35, 36, 1, 0, 0, 0, 36, 38, 1, 0, 0, 0, 37, 39, 5, 39, 0, 0, 38, 37,
-		1, 0, 0, 0, 38, 39, 1, 0, 0, 0, 39, 40, 1, 0, 0, 0, 40, 42, 5, 34, 0, 0,
-		41, 43, 5, 39, 0, 0, 42, 41, 1, 0, 0, 0, 42, 43, 1, 0, 0, 0, 43, 44, 1,
-		0, 0, 0, 44, 46, 3, 2, 1, 0, 45, 47, 5, 39, 0, 0, 46, 45, 1, 0, 0, 0, 46,
-		47, 1, 0, 0, 0, 47, 48, 1, 0, 0, 0, 48, 49, 5, 35, 0, 0, 49, 64, 1, 0,
-		0, 0, 50, 51, 3, 4, 2, 0, 51, 52, 5, 39, 0, 0, 52, 53, 5, 1, 0, 0, 53,
-		64, 1, 0, 0, 0, 54, 57, 3, 4, 2, 0, 55, 57, 3, 8, 4, 0, 56, 54, 1, 0, 0,
-		0, 56, 55, 1, 0, 0, 0, 57, 58, 1, 0, 0, 0, 58, 59, 5, 39, 0, 0, 59, 60,
-		7, 0, 0, 0, 60, 61, 5, 39, 0, 0, 61, 62, 3, 16, 8, 0, 62, 64, 1, 0, 0,
-		0, 63, 33, 1, 0, 0, 0, 63, 50, 1, 0, 0, 0, 63, 56, 1, 0, 0, 0, 64, 72,
-		1, 0, 0, 0, 65, 66, 10, 3, 0, 0, 66, 67, 5, 39, 0, 0, 67, 68, 5, 17, 0,
-		0, 68, 69, 5, 39, 0, 0, 69, 71, 3, 2, 1, 4, 70, 65, 1, 0, 0, 0, 71, 74,
-		1, 0, 0, 0, 72, 70, 1, 0, 0, 0, 72, 73, 1, 0, 0, 0, 73, 3, 1, 0, 0, 0,
-		74, 72, 1, 0, 0, 0, 75, 77, 5, 30, 0, 0, 76, 78, 3, 12, 6, 0, 77, 76, 1,
-		0, 0, 0, 77, 78, 1, 0, 0, 0, 78, 81, 1, 0, 0, 0, 79, 81, 3, 8, 4, 0, 80,
-		75, 1, 0, 0, 0, 80, 79, 1, 0, 0, 0, 81, 5, 1, 0, 0, 0, 82, 83, 7, 1, 0,
-		0, 83, 7, 1, 0, 0, 0, 84, 85, 5, 30, 0, 0, 85, 87, 5, 34, 0, 0, 86, 88,
-		3, 10, 5, 0, 87, 86, 1, 0, 0, 0, 87, 88, 1, 0, 0, 0, 88, 89, 1, 0, 0, 0,
-		89, 90, 5, 35, 0, 0, 90, 9, 1, 0, 0, 0, 91, 96, 3, 16, 8, 0, 92, 93, 5,
-		38, 0, 0, 93, 95, 3, 16, 8, 0, 94, 92, 1, 0, 0, 0, 95, 98, 1, 0, 0, 0,
-		96, 94, 1, 0, 0, 0, 96, 97, 1, 0, 0, 0, 97, 11, 1, 0, 0, 0, 98, 96, 1,
-		0, 0, 0, 99, 100, 5, 12, 0, 0, 100, 101, 3, 4, 2, 0, 101, 13, 1, 0, 0,
-		0, 102, 104, 3, 6, 3, 0, 103, 102, 1, 0, 0, 0, 103, 104, 1, 0, 0, 0, 104,
-		105, 1, 0, 0, 0, 105, 121, 5, 31, 0, 0, 106, 108, 3, 6, 3, 0, 107, 106,
-		1, 0, 0, 0, 107, 108, 1, 0, 0, 0, 108, 109, 1, 0, 0, 0, 109, 121, 5, 32,
-		0, 0, 110, 112, 3, 6, 3, 0, 111, 110, 1, 0, 0, 0, 111, 112, 1, 0, 0, 0,
-		112, 114, 1, 0, 0, 0, 113, 115, 5, 13, 0, 0, 114, 113, 1, 0, 0, 0, 114,
-		115, 1, 0, 0, 0, 115, 116, 1, 0, 0, 0, 116, 118, 5, 33, 0, 0, 117, 119,
-		5, 36, 0, 0, 118, 117, 1, 0, 0, 0, 118, 119, 1, 0, 0, 0, 119, 121, 1, 0,
-		0, 0, 120, 103, 1, 0, 0, 0, 120, 107, 1, 0, 0, 0, 120, 111, 1, 0, 0, 0,
-		121, 15, 1, 0, 0, 0, 122, 129, 3, 14, 7, 0, 123, 129, 5, 18, 0, 0, 124,
-		129, 5, 19, 0, 0, 125, 129, 3, 26, 13, 0, 126, 129, 3, 22, 11, 0, 127,
-		129, 3, 18, 9, 0, 128, 122, 1, 0, 0, 0, 128, 123, 1, 0, 0, 0, 128, 124,
-		1, 0, 0, 0, 128, 125, 1, 0, 0, 0, 128, 126, 1, 0, 0, 0, 128, 127, 1, 0,
-		0, 0, 129, 17, 1, 0, 0, 0, 130, 131, 5, 14, 0, 0, 131, 132, 3, 20, 10,
-		0, 132, 19, 1, 0, 0, 0, 133, 134, 5, 31, 0, 0, 134, 135, 5, 38, 0, 0, 135,
-		139, 3, 20, 10, 0, 136, 137, 5, 31, 0, 0, 137, 139, 5, 15, 0, 0, 138, 133,
-		1, 0, 0, 0, 138, 136, 1, 0, 0, 0, 139, 21, 1, 0, 0, 0, 140, 141, 5, 14,
-		0, 0, 141, 142, 3, 24, 12, 0, 142, 23, 1, 0, 0, 0, 143, 144, 5, 32, 0,
-		0, 144, 145, 5, 38, 0, 0, 145, 149, 3, 24, 12, 0, 146, 147, 5, 32, 0, 0,
-		147, 149, 5, 15, 0, 0, 148, 143, 1, 0, 0, 0, 148, 146, 1, 0, 0, 0, 149,
-		25, 1, 0, 0, 0, 150, 151, 5, 14, 0, 0, 151, 152, 3, 28, 14, 0, 152, 27,
-		1, 0, 0, 0, 153, 154, 5, 33, 0, 0, 154, 155, 5, 38, 0, 0, 155, 159, 3,
-		28, 14, 0, 156, 157, 5, 33, 0, 0, 157, 159, 5, 15, 0, 0, 158, 153, 1, 0,
-		0, 0, 158, 156, 1, 0, 0, 0, 159, 29, 1, 0, 0, 0, 21, 35, 38, 42, 46, 56,
-		63, 72, 77, 80, 87, 96, 103, 107, 111, 114, 118, 120, 128, 138, 148, 158,
+		10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15, 7, 15,
+		1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 3, 1, 38, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 3, 1, 45, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 51, 8, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 59, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 65,
+		8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 5, 1, 73, 8, 1, 10, 1, 12, 1,
+		76, 9, 1, 1, 2, 1, 2, 3, 2, 80, 8, 2, 1, 2, 3, 2, 83, 8, 2, 1, 3, 1, 3,
+		1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 3, 5, 92, 8, 5, 1, 5, 1, 5, 1, 6, 1, 6, 1,
+		6, 5, 6, 99, 8, 6, 10, 6, 12, 6, 102, 9, 6, 1, 7, 1, 7, 1, 7, 1, 8, 3,
+		8, 108, 8, 8, 1, 8, 1, 8, 3, 8, 112, 8, 8, 1, 8, 3, 8, 115, 8, 8, 1, 8,
+		1, 8, 3, 8, 119, 8, 8, 1, 8, 3, 8, 122, 8, 8, 1, 8, 1, 8, 3, 8, 126, 8,
+		8, 3, 8, 128, 8, 8, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 3, 9, 135, 8, 9, 1, 9,
+		1, 9, 3, 9, 139, 8, 9, 1, 9, 1, 9, 3, 9, 143, 8, 9, 1, 9, 1, 9, 1, 9, 1,
+		9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1,
+		9, 1, 9, 3, 9, 162, 8, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 5, 9, 170,
+		8, 9, 10, 9, 12, 9, 173, 9, 9, 1, 10, 1, 10, 1, 10, 1, 11, 1, 11, 1, 11,
+		1, 11, 1, 11, 3, 11, 183, 8, 11, 1, 12, 1, 12, 1, 12, 1, 13, 3, 13, 189,
+		8, 13, 1, 13, 1, 13, 1, 13, 1, 13, 3, 13, 195, 8, 13, 1, 13, 1, 13, 3,
+		13, 199, 8, 13, 1, 14, 1, 14, 1, 14, 1, 15, 3, 15, 205, 8, 15, 1, 15, 1,
+		15, 1, 15, 1, 15, 3, 15, 211, 8, 15, 1, 15, 1, 15, 3, 15, 215, 8, 15, 1,
+		15, 0, 2, 2, 18, 16, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26,
+		28, 30, 0, 6, 2, 0, 25, 36, 38, 38, 1, 0, 25, 43, 1, 0, 3, 18, 1, 0, 39,
+		41, 1, 0, 48, 50, 1, 0, 51, 52, 243, 0, 32, 1, 0, 0, 0, 2, 64, 1, 0, 0,
+		0, 4, 82, 1, 0, 0, 0, 6, 84, 1, 0, 0, 0, 8, 86, 1, 0, 0, 0, 10, 88, 1,
+		0, 0, 0, 12, 95, 1, 0, 0, 0, 14, 103, 1, 0, 0, 0, 16, 127, 1, 0, 0, 0,
+		18, 161, 1, 0, 0, 0, 20, 174, 1, 0, 0, 0, 22, 182, 1, 0, 0, 0, 24, 184,
+		1, 0, 0, 0, 26, 198, 1, 0, 0, 0, 28, 200, 1, 0, 0, 0, 30, 214, 1, 0, 0,
+		0, 32, 33, 3, 2, 1, 0, 33, 34, 5, 0, 0, 1, 34, 1, 1, 0, 0, 0, 35, 37, 6,
+		1, -1, 0, 36, 38, 5, 20, 0, 0, 37, 36, 1, 0, 0, 0, 37, 38, 1, 0, 0, 0,
+		38, 39, 1, 0, 0, 0, 39, 40, 5, 53, 0, 0, 40, 41, 3, 2, 1, 0, 41, 42, 5,
+		54, 0, 0, 42, 65, 1, 0, 0, 0, 43, 45, 5, 20, 0, 0, 44, 43, 1, 0, 0, 0,
+		44, 45, 1, 0, 0, 0, 45, 46, 1, 0, 0, 0, 46, 47, 3, 4, 2, 0, 47, 48, 5,
+		37, 0, 0, 48, 65, 1, 0, 0, 0, 49, 51, 5, 20, 0, 0, 50, 49, 1, 0, 0, 0,
+		50, 51, 1, 0, 0, 0, 51, 52, 1, 0, 0, 0, 52, 53, 3, 4, 2, 0, 53, 54, 5,
+		1, 0, 0, 54, 55, 3, 2, 1, 0, 55, 56, 5, 2, 0, 0, 56, 65, 1, 0, 0, 0, 57,
+		59, 5, 20, 0, 0, 58, 57, 1, 0, 0, 0, 58, 59, 1, 0, 0, 0, 59, 60, 1, 0,
+		0, 0, 60, 61, 3, 18, 9, 0, 61, 62, 7, 0, 0, 0, 62, 63, 3, 18, 9, 0, 63,
+		65, 1, 0, 0, 0, 64, 35, 1, 0, 0, 0, 64, 44, 1, 0, 0, 0, 64, 50, 1, 0, 0,
+		0, 64, 58, 1, 0, 0, 0, 65, 74, 1, 0, 0, 0, 66, 67, 10, 5, 0, 0, 67, 68,
+		5, 21, 0, 0, 68, 73, 3, 2, 1, 6, 69, 70, 10, 4, 0, 0, 70, 71, 5, 22, 0,
+		0, 71, 73, 3, 2, 1, 5, 72, 66, 1, 0, 0, 0, 72, 69, 1, 0, 0, 0, 73, 76,
+		1, 0, 0, 0, 74, 72, 1, 0, 0, 0, 74, 75, 1, 0, 0, 0, 75, 3, 1, 0, 0, 0,
+		76, 74, 1, 0, 0, 0, 77, 79, 3, 6, 3, 0, 78, 80, 3, 14, 7, 0, 79, 78, 1,
+		0, 0, 0, 79, 80, 1, 0, 0, 0, 80, 83, 1, 0, 0, 0, 81, 83, 3, 10, 5, 0, 82,
+		77, 1, 0, 0, 0, 82, 81, 1, 0, 0, 0, 83, 5, 1, 0, 0, 0, 84, 85, 7, 1, 0,
+		0, 85, 7, 1, 0, 0, 0, 86, 87, 7, 2, 0, 0, 87, 9, 1, 0, 0, 0, 88, 89, 5,
+		43, 0, 0, 89, 91, 5, 53, 0, 0, 90, 92, 3, 12, 6, 0, 91, 90, 1, 0, 0, 0,
+		91, 92, 1, 0, 0, 0, 92, 93, 1, 0, 0, 0, 93, 94, 5, 54, 0, 0, 94, 11, 1,
+		0, 0, 0, 95, 100, 3, 18, 9, 0, 96, 97, 5, 56, 0, 0, 97, 99, 3, 18, 9, 0,
+		98, 96, 1, 0, 0, 0, 99, 102, 1, 0, 0, 0, 100, 98, 1, 0, 0, 0, 100, 101,
+		1, 0, 0, 0, 101, 13, 1, 0, 0, 0, 102, 100, 1, 0, 0, 0, 103, 104, 5, 19,
+		0, 0, 104, 105, 3, 4, 2, 0, 105, 15, 1, 0, 0, 0, 106, 108, 3, 8, 4, 0,
+		107, 106, 1, 0, 0, 0, 107, 108, 1, 0, 0, 0, 108, 109, 1, 0, 0, 0, 109,
+		128, 5, 44, 0, 0, 110, 112, 3, 8, 4, 0, 111, 110, 1, 0, 0, 0, 111, 112,
+		1, 0, 0, 0, 112, 114, 1, 0, 0, 0, 113, 115, 5, 52, 0, 0, 114, 113, 1, 0,
+		0, 0, 114, 115, 1, 0, 0, 0, 115, 116, 1, 0, 0, 0, 116, 128, 5, 45, 0, 0,
+		117, 119, 3, 8, 4, 0, 118, 117, 1, 0, 0, 0, 118, 119, 1, 0, 0, 0, 119,
+		121, 1, 0, 0, 0, 120, 122, 5, 52, 0, 0, 121, 120, 1, 0, 0, 0, 121, 122,
+		1, 0, 0, 0, 122, 123, 1, 0, 0, 0, 123, 125, 5, 47, 0, 0, 124, 126, 5, 55,
+		0, 0, 125, 124, 1, 0, 0, 0, 125, 126, 1, 0, 0, 0, 126, 128, 1, 0, 0, 0,
+		127, 107, 1, 0, 0, 0, 127, 111, 1, 0, 0, 0, 127, 118, 1, 0, 0, 0, 128,
+		17, 1, 0, 0, 0, 129, 130, 6, 9, -1, 0, 130, 162, 3, 16, 8, 0, 131, 162,
+		5, 23, 0, 0, 132, 162, 5, 24, 0, 0, 133, 135, 3, 8, 4, 0, 134, 133, 1,
+		0, 0, 0, 134, 135, 1, 0, 0, 0, 135, 136, 1, 0, 0, 0, 136, 162, 3, 28, 14,
+		0, 137, 139, 3, 8, 4, 0, 138, 137, 1, 0, 0, 0, 138, 139, 1, 0, 0, 0, 139,
+		140, 1, 0, 0, 0, 140, 162, 3, 24, 12, 0, 141, 143, 3, 8, 4, 0, 142, 141,
+		1, 0, 0, 0, 142, 143, 1, 0, 0, 0, 143, 144, 1, 0, 0, 0, 144, 162, 3, 20,
+		10, 0, 145, 162, 5, 46, 0, 0, 146, 147, 7, 3, 0, 0, 147, 148, 5, 53, 0,
+		0, 148, 149, 3, 4, 2, 0, 149, 150, 5, 54, 0, 0, 150, 162, 1, 0, 0, 0, 151,
+		152, 5, 42, 0, 0, 152, 153, 5, 53, 0, 0, 153, 154, 3, 4, 2, 0, 154, 155,
+		5, 54, 0, 0, 155, 162, 1, 0, 0, 0, 156, 162, 3, 4, 2, 0, 157, 158, 5, 53,
+		0, 0, 158, 159, 3, 18, 9, 0, 159, 160, 5, 54, 0, 0, 160, 162, 1, 0, 0,
+		0, 161, 129, 1, 0, 0, 0, 161, 131, 1, 0, 0, 0, 161, 132, 1, 0, 0, 0, 161,
+		134, 1, 0, 0, 0, 161, 138, 1, 0, 0, 0, 161, 142, 1, 0, 0, 0, 161, 145,
+		1, 0, 0, 0, 161, 146, 1, 0, 0, 0, 161, 151, 1, 0, 0, 0, 161, 156, 1, 0,
+		0, 0, 161, 157, 1, 0, 0, 0, 162, 171, 1, 0, 0, 0, 163, 164, 10, 2, 0, 0,
+		164, 165, 7, 4, 0, 0, 165, 170, 3, 18, 9, 3, 166, 167, 10, 1, 0, 0, 167,
+		168, 7, 5, 0, 0, 168, 170, 3, 18, 9, 2, 169, 163, 1, 0, 0, 0, 169, 166,
+		1, 0, 0, 0, 170, 173, 1, 0, 0, 0, 171, 169, 1, 0, 0, 0, 171, 172, 1, 0,
+		0, 0, 172, 19, 1, 0, 0, 0, 173, 171, 1, 0, 0, 0, 174, 175, 5, 1, 0, 0,
+		175, 176, 3, 22, 11, 0, 176, 21, 1, 0, 0, 0, 177, 178, 5, 44, 0, 0, 178,
+		179, 5, 56, 0, 0, 179, 183, 3, 22, 11, 0, 180, 181, 5, 44, 0, 0, 181, 183,
+		5, 2, 0, 0, 182, 177, 1, 0, 0, 0, 182, 180, 1, 0, 0, 0, 183, 23, 1, 0,
+		0, 0, 184, 185, 5, 1, 0, 0, 185, 186, 3, 26, 13, 0, 186, 25, 1, 0, 0, 0,
+		187, 189, 5, 52, 0, 0, 188, 187, 1, 0, 0, 0, 188, 189, 1, 0, 0, 0, 189,
+		190, 1, 0, 0, 0, 190, 191, 5, 45, 0, 0, 191, 192, 5, 56, 0, 0, 192, 199,
+		3, 26, 13, 0, 193, 195, 5, 52, 0, 0, 194, 193, 1, 0, 0, 0, 194, 195, 1,
+		0, 0, 0, 195, 196, 1, 0, 0, 0, 196, 197, 5, 45, 0, 0, 197, 199, 5, 2, 0,
+		0, 198, 188, 1, 0, 0, 0, 198, 194, 1, 0, 0, 0, 199, 27, 1, 0, 0, 0, 200,
+		201, 5, 1, 0, 0, 201, 202, 3, 30, 15, 0, 202, 29, 1, 0, 0, 0, 203, 205,
+		5, 52, 0, 0, 204, 203, 1, 0, 0, 0, 204, 205, 1, 0, 0, 0, 205, 206, 1, 0,
+		0, 0, 206, 207, 5, 47, 0, 0, 207, 208, 5, 56, 0, 0, 208, 215, 3, 30, 15,
+		0, 209, 211, 5, 52, 0, 0, 210, 209, 1, 0, 0, 0, 210, 211, 1, 0, 0, 0, 211,
+		212, 1, 0, 0, 0, 212, 213, 5, 47, 0, 0, 213, 215, 5, 2, 0, 0, 214, 204,
+		1, 0, 0, 0, 214, 210, 1, 0, 0, 0, 215, 31, 1, 0, 0, 0, 31, 37, 44, 50,
+		58, 64, 72, 74, 79, 82, 91, 100, 107, 111, 114, 118, 121, 125, 127, 134,
+		138, 142, 161, 169, 171, 182, 188, 194, 198, 204, 210, 214,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -157,46 +190,64 @@ func NewSCIMQueryParser(input antlr.TokenStream) *SCIMQueryParser {
 
 // SCIMQueryParser tokens.
 const (
-	SCIMQueryParserEOF              = antlr.TokenEOF
-	SCIMQueryParserT__0             = 1
-	SCIMQueryParserT__1             = 2
-	SCIMQueryParserT__2             = 3
-	SCIMQueryParserT__3             = 4
-	SCIMQueryParserT__4             = 5
-	SCIMQueryParserT__5             = 6
-	SCIMQueryParserT__6             = 7
-	SCIMQueryParserT__7             = 8
-	SCIMQueryParserT__8             = 9
-	SCIMQueryParserT__9             = 10
-	SCIMQueryParserT__10            = 11
-	SCIMQueryParserT__11            = 12
-	SCIMQueryParserT__12            = 13
-	SCIMQueryParserT__13            = 14
-	SCIMQueryParserT__14            = 15
-	SCIMQueryParserNOT              = 16
-	SCIMQueryParserLOGICAL_OPERATOR = 17
-	SCIMQueryParserBOOLEAN          = 18
-	SCIMQueryParserNULL             = 19
-	SCIMQueryParserIN               = 20
-	SCIMQueryParserEQ               = 21
-	SCIMQueryParserNE               = 22
-	SCIMQueryParserGT               = 23
-	SCIMQueryParserLT               = 24
-	SCIMQueryParserGE               = 25
-	SCIMQueryParserLE               = 26
-	SCIMQueryParserCO               = 27
-	SCIMQueryParserSW               = 28
-	SCIMQueryParserEW               = 29
-	SCIMQueryParserATTRNAME         = 30
-	SCIMQueryParserSTRING           = 31
-	SCIMQueryParserDOUBLE           = 32
-	SCIMQueryParserINT              = 33
-	SCIMQueryParserLPAREN           = 34
-	SCIMQueryParserRPAREN           = 35
-	SCIMQueryParserEXP              = 36
-	SCIMQueryParserNEWLINE          = 37
-	SCIMQueryParserCOMMA            = 38
-	SCIMQueryParserSP               = 39
+	SCIMQueryParserEOF      = antlr.TokenEOF
+	SCIMQueryParserT__0     = 1
+	SCIMQueryParserT__1     = 2
+	SCIMQueryParserT__2     = 3
+	SCIMQueryParserT__3     = 4
+	SCIMQueryParserT__4     = 5
+	SCIMQueryParserT__5     = 6
+	SCIMQueryParserT__6     = 7
+	SCIMQueryParserT__7     = 8
+	SCIMQueryParserT__8     = 9
+	SCIMQueryParserT__9     = 10
+	SCIMQueryParserT__10    = 11
+	SCIMQueryParserT__11    = 12
+	SCIMQueryParserT__12    = 13
+	SCIMQueryParserT__13    = 14
+	SCIMQueryParserT__14    = 15
+	SCIMQueryParserT__15    = 16
+	SCIMQueryParserT__16    = 17
+	SCIMQueryParserT__17    = 18
+	SCIMQueryParserT__18    = 19
+	SCIMQueryParserNOT      = 20
+	SCIMQueryParserAND      = 21
+	SCIMQueryParserOR       = 22
+	SCIMQueryParserBOOLEAN  = 23
+	SCIMQueryParserNULL     = 24
+	SCIMQueryParserIN       = 25
+	SCIMQueryParserNIN      = 26
+	SCIMQueryParserEQ       = 27
+	SCIMQueryParserNE       = 28
+	SCIMQueryParserGT       = 29
+	SCIMQueryParserLT       = 30
+	SCIMQueryParserGE       = 31
+	SCIMQueryParserLE       = 32
+	SCIMQueryParserCO       = 33
+	SCIMQueryParserSW       = 34
+	SCIMQueryParserEW       = 35
+	SCIMQueryParserMR       = 36
+	SCIMQueryParserPR       = 37
+	SCIMQueryParserWI       = 38
+	SCIMQueryParserANY      = 39
+	SCIMQueryParserALL      = 40
+	SCIMQueryParserNONE     = 41
+	SCIMQueryParserLEN      = 42
+	SCIMQueryParserATTRNAME = 43
+	SCIMQueryParserSTRING   = 44
+	SCIMQueryParserDOUBLE   = 45
+	SCIMQueryParserDURATION = 46
+	SCIMQueryParserINT      = 47
+	SCIMQueryParserSTAR     = 48
+	SCIMQueryParserSLASH    = 49
+	SCIMQueryParserPERCENT  = 50
+	SCIMQueryParserPLUS     = 51
+	SCIMQueryParserMINUS    = 52
+	SCIMQueryParserLPAREN   = 53
+	SCIMQueryParserRPAREN   = 54
+	SCIMQueryParserEXP      = 55
+	SCIMQueryParserCOMMA    = 56
+	SCIMQueryParserWS       = 57
 )
 
 // SCIMQueryParser rules.
@@ -204,18 +255,19 @@ const (
 	SCIMQueryParserRULE_root             = 0
 	SCIMQueryParserRULE_query            = 1
 	SCIMQueryParserRULE_attrPath         = 2
-	SCIMQueryParserRULE_typeAnnotation   = 3
-	SCIMQueryParserRULE_functionCall     = 4
-	SCIMQueryParserRULE_argList          = 5
-	SCIMQueryParserRULE_subAttr          = 6
-	SCIMQueryParserRULE_typedValue       = 7
-	SCIMQueryParserRULE_value            = 8
-	SCIMQueryParserRULE_listStrings      = 9
-	SCIMQueryParserRULE_subListOfStrings = 10
-	SCIMQueryParserRULE_listDoubles      = 11
-	SCIMQueryParserRULE_subListOfDoubles = 12
-	SCIMQueryParserRULE_listInts         = 13
-	SCIMQueryParserRULE_subListOfInts    = 14
+	SCIMQueryParserRULE_attrName         = 3
+	SCIMQueryParserRULE_typeAnnotation   = 4
+	SCIMQueryParserRULE_functionCall     = 5
+	SCIMQueryParserRULE_argList          = 6
+	SCIMQueryParserRULE_subAttr          = 7
+	SCIMQueryParserRULE_typedValue       = 8
+	SCIMQueryParserRULE_value            = 9
+	SCIMQueryParserRULE_listStrings      = 10
+	SCIMQueryParserRULE_subListOfStrings = 11
+	SCIMQueryParserRULE_listDoubles      = 12
+	SCIMQueryParserRULE_subListOfDoubles = 13
+	SCIMQueryParserRULE_listInts         = 14
+	SCIMQueryParserRULE_subListOfInts    = 15
 )
 
 // IRootContext is an interface to support dynamic dispatch.
@@ -320,11 +372,11 @@ func (p *SCIMQueryParser) Root() (localctx IRootContext) {
 	p.EnterRule(localctx, 0, SCIMQueryParserRULE_root)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(30)
+		p.SetState(32)
 		p.query(0)
 	}
 	{
-		p.SetState(31)
+		p.SetState(33)
 		p.Match(SCIMQueryParserEOF)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -422,52 +474,37 @@ func (s *CompareExpContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *CompareExpContext) AllSP() []antlr.TerminalNode {
-	return s.GetTokens(SCIMQueryParserSP)
-}
-
-func (s *CompareExpContext) SP(i int) antlr.TerminalNode {
-	return s.GetToken(SCIMQueryParserSP, i)
-}
-
-func (s *CompareExpContext) Value() IValueContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
+func (s *CompareExpContext) AllValue() []IValueContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
 		if _, ok := ctx.(IValueContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+			len++
 		}
 	}
 
-	if t == nil {
-		return nil
-	}
-
-	return t.(IValueContext)
-}
-
-func (s *CompareExpContext) AttrPath() IAttrPathContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IAttrPathContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+	tst := make([]IValueContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IValueContext); ok {
+			tst[i] = t.(IValueContext)
+			i++
 		}
 	}
 
-	if t == nil {
-		return nil
-	}
-
-	return t.(IAttrPathContext)
+	return tst
 }
 
-func (s *CompareExpContext) FunctionCall() IFunctionCallContext {
+func (s *CompareExpContext) Value(i int) IValueContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IFunctionCallContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+		if _, ok := ctx.(IValueContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -475,7 +512,7 @@ func (s *CompareExpContext) FunctionCall() IFunctionCallContext {
 		return nil
 	}
 
-	return t.(IFunctionCallContext)
+	return t.(IValueContext)
 }
 
 func (s *CompareExpContext) EQ() antlr.TerminalNode {
@@ -518,6 +555,22 @@ func (s *CompareExpContext) IN() antlr.TerminalNode {
 	return s.GetToken(SCIMQueryParserIN, 0)
 }
 
+func (s *CompareExpContext) NIN() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserNIN, 0)
+}
+
+func (s *CompareExpContext) MR() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserMR, 0)
+}
+
+func (s *CompareExpContext) WI() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserWI, 0)
+}
+
+func (s *CompareExpContext) NOT() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserNOT, 0)
+}
+
 func (s *CompareExpContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SCIMQueryListener); ok {
 		listenerT.EnterCompareExp(s)
@@ -586,14 +639,6 @@ func (s *ParenExpContext) NOT() antlr.TerminalNode {
 	return s.GetToken(SCIMQueryParserNOT, 0)
 }
 
-func (s *ParenExpContext) AllSP() []antlr.TerminalNode {
-	return s.GetTokens(SCIMQueryParserSP)
-}
-
-func (s *ParenExpContext) SP(i int) antlr.TerminalNode {
-	return s.GetToken(SCIMQueryParserSP, i)
-}
-
 func (s *ParenExpContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SCIMQueryListener); ok {
 		listenerT.EnterParenExp(s)
@@ -650,8 +695,12 @@ func (s *PresentExpContext) AttrPath() IAttrPathContext {
 	return t.(IAttrPathContext)
 }
 
-func (s *PresentExpContext) SP() antlr.TerminalNode {
-	return s.GetToken(SCIMQueryParserSP, 0)
+func (s *PresentExpContext) PR() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserPR, 0)
+}
+
+func (s *PresentExpContext) NOT() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserNOT, 0)
 }
 
 func (s *PresentExpContext) EnterRule(listener antlr.ParseTreeListener) {
@@ -676,8 +725,85 @@ func (s *PresentExpContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	}
 }
 
+type ValuePathExpContext struct {
+	QueryContext
+}
+
+func NewValuePathExpContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ValuePathExpContext {
+	var p = new(ValuePathExpContext)
+
+	InitEmptyQueryContext(&p.QueryContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*QueryContext))
+
+	return p
+}
+
+func (s *ValuePathExpContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ValuePathExpContext) AttrPath() IAttrPathContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IAttrPathContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAttrPathContext)
+}
+
+func (s *ValuePathExpContext) Query() IQueryContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IQueryContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IQueryContext)
+}
+
+func (s *ValuePathExpContext) NOT() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserNOT, 0)
+}
+
+func (s *ValuePathExpContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.EnterValuePathExp(s)
+	}
+}
+
+func (s *ValuePathExpContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.ExitValuePathExp(s)
+	}
+}
+
+func (s *ValuePathExpContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SCIMQueryVisitor:
+		return t.VisitValuePathExp(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
 type LogicalExpContext struct {
 	QueryContext
+	op antlr.Token
 }
 
 func NewLogicalExpContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LogicalExpContext {
@@ -690,6 +816,10 @@ func NewLogicalExpContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *Log
 	return p
 }
 
+func (s *LogicalExpContext) GetOp() antlr.Token { return s.op }
+
+func (s *LogicalExpContext) SetOp(v antlr.Token) { s.op = v }
+
 func (s *LogicalExpContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -735,16 +865,12 @@ func (s *LogicalExpContext) Query(i int) IQueryContext {
 	return t.(IQueryContext)
 }
 
-func (s *LogicalExpContext) AllSP() []antlr.TerminalNode {
-	return s.GetTokens(SCIMQueryParserSP)
+func (s *LogicalExpContext) AND() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserAND, 0)
 }
 
-func (s *LogicalExpContext) SP(i int) antlr.TerminalNode {
-	return s.GetToken(SCIMQueryParserSP, i)
-}
-
-func (s *LogicalExpContext) LOGICAL_OPERATOR() antlr.TerminalNode {
-	return s.GetToken(SCIMQueryParserLOGICAL_OPERATOR, 0)
+func (s *LogicalExpContext) OR() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserOR, 0)
 }
 
 func (s *LogicalExpContext) EnterRule(listener antlr.ParseTreeListener) {
@@ -787,19 +913,19 @@ func (p *SCIMQueryParser) query(_p int) (localctx IQueryContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(63)
+	p.SetState(64)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 5, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 4, p.GetParserRuleContext()) {
 	case 1:
 		localctx = NewParenExpContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 
-		p.SetState(35)
+		p.SetState(37)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -808,7 +934,7 @@ func (p *SCIMQueryParser) query(_p int) (localctx IQueryContext) {
 
 		if _la == SCIMQueryParserNOT {
 			{
-				p.SetState(34)
+				p.SetState(36)
 				p.Match(SCIMQueryParserNOT)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -817,63 +943,77 @@ func (p *SCIMQueryParser) query(_p int) (localctx IQueryContext) {
 			}
 
 		}
-		p.SetState(38)
-		p.GetErrorHandler().Sync(p)
-		if p.HasError() {
-			goto errorExit
-		}
-		_la = p.GetTokenStream().LA(1)
-
-		if _la == SCIMQueryParserSP {
-			{
-				p.SetState(37)
-				p.Match(SCIMQueryParserSP)
-				if p.HasError() {
-					// Recognition error - abort rule
-					goto errorExit
-				}
+		{
+			p.SetState(39)
+			p.Match(SCIMQueryParserLPAREN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
 			}
-
 		}
 		{
 			p.SetState(40)
-			p.Match(SCIMQueryParserLPAREN)
+			p.query(0)
+		}
+		{
+			p.SetState(41)
+			p.Match(SCIMQueryParserRPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		p.SetState(42)
+
+	case 2:
+		localctx = NewPresentExpContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		p.SetState(44)
 		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
 
-		if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 2, p.GetParserRuleContext()) == 1 {
+		if _la == SCIMQueryParserNOT {
 			{
-				p.SetState(41)
-				p.Match(SCIMQueryParserSP)
+				p.SetState(43)
+				p.Match(SCIMQueryParserNOT)
 				if p.HasError() {
 					// Recognition error - abort rule
 					goto errorExit
 				}
 			}
 
-		} else if p.HasError() { // JIM
-			goto errorExit
 		}
 		{
-			p.SetState(44)
-			p.query(0)
+			p.SetState(46)
+			p.AttrPath()
+		}
+		{
+			p.SetState(47)
+			p.Match(SCIMQueryParserPR)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
 		}
-		p.SetState(46)
+
+	case 3:
+		localctx = NewValuePathExpContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		p.SetState(50)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
 
-		if _la == SCIMQueryParserSP {
+		if _la == SCIMQueryParserNOT {
 			{
-				p.SetState(45)
-				p.Match(SCIMQueryParserSP)
+				p.SetState(49)
+				p.Match(SCIMQueryParserNOT)
 				if p.HasError() {
 					// Recognition error - abort rule
 					goto errorExit
@@ -882,75 +1022,58 @@ func (p *SCIMQueryParser) query(_p int) (localctx IQueryContext) {
 
 		}
 		{
-			p.SetState(48)
-			p.Match(SCIMQueryParserRPAREN)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
-			}
-		}
-
-	case 2:
-		localctx = NewPresentExpContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
-		{
-			p.SetState(50)
+			p.SetState(52)
 			p.AttrPath()
 		}
 		{
-			p.SetState(51)
-			p.Match(SCIMQueryParserSP)
+			p.SetState(53)
+			p.Match(SCIMQueryParserT__0)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
 		{
-			p.SetState(52)
-			p.Match(SCIMQueryParserT__0)
+			p.SetState(54)
+			p.query(0)
+		}
+		{
+			p.SetState(55)
+			p.Match(SCIMQueryParserT__1)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
 
-	case 3:
+	case 4:
 		localctx = NewCompareExpContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
-		p.SetState(56)
+		p.SetState(58)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
+		_la = p.GetTokenStream().LA(1)
 
-		switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 4, p.GetParserRuleContext()) {
-		case 1:
-			{
-				p.SetState(54)
-				p.AttrPath()
-			}
-
-		case 2:
+		if _la == SCIMQueryParserNOT {
 			{
-				p.SetState(55)
-				p.FunctionCall()
+				p.SetState(57)
+				p.Match(SCIMQueryParserNOT)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
 			}
 
-		case antlr.ATNInvalidAltNumber:
-			goto errorExit
 		}
 		{
-			p.SetState(58)
-			p.Match(SCIMQueryParserSP)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
-			}
+			p.SetState(60)
+			p.value(0)
 		}
 		{
-			p.SetState(59)
+			p.SetState(61)
 
 			var _lt = p.GetTokenStream().LT(1)
 
@@ -958,7 +1081,7 @@ func (p *SCIMQueryParser) query(_p int) (localctx IQueryContext) {
 
 			_la = p.GetTokenStream().LA(1)
 
-			if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&1072693248) != 0) {
+			if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&412283305984) != 0) {
 				var _ri = p.GetErrorHandler().RecoverInline(p)
 
 				localctx.(*CompareExpContext).op = _ri
@@ -968,23 +1091,15 @@ func (p *SCIMQueryParser) query(_p int) (localctx IQueryContext) {
 			}
 		}
 		{
-			p.SetState(60)
-			p.Match(SCIMQueryParserSP)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
-			}
-		}
-		{
-			p.SetState(61)
-			p.Value()
+			p.SetState(62)
+			p.value(0)
 		}
 
 	case antlr.ATNInvalidAltNumber:
 		goto errorExit
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(72)
+	p.SetState(74)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -999,48 +1114,72 @@ func (p *SCIMQueryParser) query(_p int) (localctx IQueryContext) {
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			localctx = NewLogicalExpContext(p, NewQueryContext(p, _parentctx, _parentState))
-			p.PushNewRecursionContext(localctx, _startState, SCIMQueryParserRULE_query)
-			p.SetState(65)
-
-			if !(p.Precpred(p.GetParserRuleContext(), 3)) {
-				p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
+			p.SetState(72)
+			p.GetErrorHandler().Sync(p)
+			if p.HasError() {
 				goto errorExit
 			}
-			{
+
+			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 5, p.GetParserRuleContext()) {
+			case 1:
+				localctx = NewLogicalExpContext(p, NewQueryContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, SCIMQueryParserRULE_query)
 				p.SetState(66)
-				p.Match(SCIMQueryParserSP)
-				if p.HasError() {
-					// Recognition error - abort rule
+
+				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
 					goto errorExit
 				}
-			}
-			{
-				p.SetState(67)
-				p.Match(SCIMQueryParserLOGICAL_OPERATOR)
-				if p.HasError() {
-					// Recognition error - abort rule
-					goto errorExit
+				{
+					p.SetState(67)
+
+					var _m = p.Match(SCIMQueryParserAND)
+
+					localctx.(*LogicalExpContext).op = _m
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
 				}
-			}
-			{
-				p.SetState(68)
-				p.Match(SCIMQueryParserSP)
-				if p.HasError() {
-					// Recognition error - abort rule
-					goto errorExit
+				{
+					p.SetState(68)
+					p.query(6)
 				}
-			}
-			{
+
+			case 2:
+				localctx = NewLogicalExpContext(p, NewQueryContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, SCIMQueryParserRULE_query)
 				p.SetState(69)
-				p.query(4)
-			}
 
-		}
-		p.SetState(74)
-		p.GetErrorHandler().Sync(p)
-		if p.HasError() {
-			goto errorExit
+				if !(p.Precpred(p.GetParserRuleContext(), 4)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 4)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(70)
+
+					var _m = p.Match(SCIMQueryParserOR)
+
+					localctx.(*LogicalExpContext).op = _m
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(71)
+					p.query(5)
+				}
+
+			case antlr.ATNInvalidAltNumber:
+				goto errorExit
+			}
+
+		}
+		p.SetState(76)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
 		}
 		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 6, p.GetParserRuleContext())
 		if p.HasError() {
@@ -1069,7 +1208,7 @@ type IAttrPathContext interface {
 	GetParser() antlr.Parser
 
 	// Getter signatures
-	ATTRNAME() antlr.TerminalNode
+	AttrName() IAttrNameContext
 	SubAttr() ISubAttrContext
 	FunctionCall() IFunctionCallContext
 
@@ -1109,8 +1248,20 @@ func NewAttrPathContext(parser antlr.Parser, parent antlr.ParserRuleContext, inv
 
 func (s *AttrPathContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *AttrPathContext) ATTRNAME() antlr.TerminalNode {
-	return s.GetToken(SCIMQueryParserATTRNAME, 0)
+func (s *AttrPathContext) AttrName() IAttrNameContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IAttrNameContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAttrNameContext)
 }
 
 func (s *AttrPathContext) SubAttr() ISubAttrContext {
@@ -1178,9 +1329,7 @@ func (s *AttrPathContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 func (p *SCIMQueryParser) AttrPath() (localctx IAttrPathContext) {
 	localctx = NewAttrPathContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 4, SCIMQueryParserRULE_attrPath)
-	var _la int
-
-	p.SetState(80)
+	p.SetState(82)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -1190,32 +1339,26 @@ func (p *SCIMQueryParser) AttrPath() (localctx IAttrPathContext) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(75)
-			p.Match(SCIMQueryParserATTRNAME)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
-			}
+			p.SetState(77)
+			p.AttrName()
 		}
-		p.SetState(77)
+		p.SetState(79)
 		p.GetErrorHandler().Sync(p)
-		if p.HasError() {
-			goto errorExit
-		}
-		_la = p.GetTokenStream().LA(1)
 
-		if _la == SCIMQueryParserT__11 {
+		if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 7, p.GetParserRuleContext()) == 1 {
 			{
-				p.SetState(76)
+				p.SetState(78)
 				p.SubAttr()
 			}
 
+		} else if p.HasError() { // JIM
+			goto errorExit
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(79)
+			p.SetState(81)
 			p.FunctionCall()
 		}
 
@@ -1236,6 +1379,207 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
+// IAttrNameContext is an interface to support dynamic dispatch.
+type IAttrNameContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	ATTRNAME() antlr.TerminalNode
+	ANY() antlr.TerminalNode
+	ALL() antlr.TerminalNode
+	NONE() antlr.TerminalNode
+	LEN() antlr.TerminalNode
+	IN() antlr.TerminalNode
+	NIN() antlr.TerminalNode
+	EQ() antlr.TerminalNode
+	NE() antlr.TerminalNode
+	GT() antlr.TerminalNode
+	LT() antlr.TerminalNode
+	GE() antlr.TerminalNode
+	LE() antlr.TerminalNode
+	CO() antlr.TerminalNode
+	SW() antlr.TerminalNode
+	EW() antlr.TerminalNode
+	MR() antlr.TerminalNode
+	PR() antlr.TerminalNode
+	WI() antlr.TerminalNode
+
+	// IsAttrNameContext differentiates from other interfaces.
+	IsAttrNameContext()
+}
+
+type AttrNameContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyAttrNameContext() *AttrNameContext {
+	var p = new(AttrNameContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SCIMQueryParserRULE_attrName
+	return p
+}
+
+func InitEmptyAttrNameContext(p *AttrNameContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = SCIMQueryParserRULE_attrName
+}
+
+func (*AttrNameContext) IsAttrNameContext() {}
+
+func NewAttrNameContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *AttrNameContext {
+	var p = new(AttrNameContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SCIMQueryParserRULE_attrName
+
+	return p
+}
+
+func (s *AttrNameContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *AttrNameContext) ATTRNAME() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserATTRNAME, 0)
+}
+
+func (s *AttrNameContext) ANY() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserANY, 0)
+}
+
+func (s *AttrNameContext) ALL() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserALL, 0)
+}
+
+func (s *AttrNameContext) NONE() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserNONE, 0)
+}
+
+func (s *AttrNameContext) LEN() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserLEN, 0)
+}
+
+func (s *AttrNameContext) IN() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserIN, 0)
+}
+
+func (s *AttrNameContext) NIN() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserNIN, 0)
+}
+
+func (s *AttrNameContext) EQ() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserEQ, 0)
+}
+
+func (s *AttrNameContext) NE() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserNE, 0)
+}
+
+func (s *AttrNameContext) GT() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserGT, 0)
+}
+
+func (s *AttrNameContext) LT() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserLT, 0)
+}
+
+func (s *AttrNameContext) GE() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserGE, 0)
+}
+
+func (s *AttrNameContext) LE() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserLE, 0)
+}
+
+func (s *AttrNameContext) CO() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserCO, 0)
+}
+
+func (s *AttrNameContext) SW() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserSW, 0)
+}
+
+func (s *AttrNameContext) EW() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserEW, 0)
+}
+
+func (s *AttrNameContext) MR() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserMR, 0)
+}
+
+func (s *AttrNameContext) PR() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserPR, 0)
+}
+
+func (s *AttrNameContext) WI() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserWI, 0)
+}
+
+func (s *AttrNameContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AttrNameContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *AttrNameContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.EnterAttrName(s)
+	}
+}
+
+func (s *AttrNameContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.ExitAttrName(s)
+	}
+}
+
+func (s *AttrNameContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SCIMQueryVisitor:
+		return t.VisitAttrName(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SCIMQueryParser) AttrName() (localctx IAttrNameContext) {
+	localctx = NewAttrNameContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 6, SCIMQueryParserRULE_attrName)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(84)
+		_la = p.GetTokenStream().LA(1)
+
+		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&17592152489984) != 0) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
 // ITypeAnnotationContext is an interface to support dynamic dispatch.
 type ITypeAnnotationContext interface {
 	antlr.ParserRuleContext
@@ -1309,15 +1653,15 @@ func (s *TypeAnnotationContext) Accept(visitor antlr.ParseTreeVisitor) interface
 
 func (p *SCIMQueryParser) TypeAnnotation() (localctx ITypeAnnotationContext) {
 	localctx = NewTypeAnnotationContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 6, SCIMQueryParserRULE_typeAnnotation)
+	p.EnterRule(localctx, 8, SCIMQueryParserRULE_typeAnnotation)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(82)
+		p.SetState(86)
 		_la = p.GetTokenStream().LA(1)
 
-		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&4092) != 0) {
+		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&524280) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -1447,12 +1791,12 @@ func (s *FunctionCallContext) Accept(visitor antlr.ParseTreeVisitor) interface{}
 
 func (p *SCIMQueryParser) FunctionCall() (localctx IFunctionCallContext) {
 	localctx = NewFunctionCallContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 8, SCIMQueryParserRULE_functionCall)
+	p.EnterRule(localctx, 10, SCIMQueryParserRULE_functionCall)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(84)
+		p.SetState(88)
 		p.Match(SCIMQueryParserATTRNAME)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1460,29 +1804,29 @@ func (p *SCIMQueryParser) FunctionCall() (localctx IFunctionCallContext) {
 		}
 	}
 	{
-		p.SetState(85)
+		p.SetState(89)
 		p.Match(SCIMQueryParserLPAREN)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(87)
+	p.SetState(91)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 	_la = p.GetTokenStream().LA(1)
 
-	if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&15033200636) != 0 {
+	if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&13792273850957818) != 0 {
 		{
-			p.SetState(86)
+			p.SetState(90)
 			p.ArgList()
 		}
 
 	}
 	{
-		p.SetState(89)
+		p.SetState(93)
 		p.Match(SCIMQueryParserRPAREN)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -1633,15 +1977,15 @@ func (s *ArgListContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *SCIMQueryParser) ArgList() (localctx IArgListContext) {
 	localctx = NewArgListContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 10, SCIMQueryParserRULE_argList)
+	p.EnterRule(localctx, 12, SCIMQueryParserRULE_argList)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(91)
-		p.Value()
+		p.SetState(95)
+		p.value(0)
 	}
-	p.SetState(96)
+	p.SetState(100)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -1650,7 +1994,7 @@ func (p *SCIMQueryParser) ArgList() (localctx IArgListContext) {
 
 	for _la == SCIMQueryParserCOMMA {
 		{
-			p.SetState(92)
+			p.SetState(96)
 			p.Match(SCIMQueryParserCOMMA)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1658,11 +2002,11 @@ func (p *SCIMQueryParser) ArgList() (localctx IArgListContext) {
 			}
 		}
 		{
-			p.SetState(93)
-			p.Value()
+			p.SetState(97)
+			p.value(0)
 		}
 
-		p.SetState(98)
+		p.SetState(102)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -1777,18 +2121,18 @@ func (s *SubAttrContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *SCIMQueryParser) SubAttr() (localctx ISubAttrContext) {
 	localctx = NewSubAttrContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 12, SCIMQueryParserRULE_subAttr)
+	p.EnterRule(localctx, 14, SCIMQueryParserRULE_subAttr)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(99)
-		p.Match(SCIMQueryParserT__11)
+		p.SetState(103)
+		p.Match(SCIMQueryParserT__18)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
 	{
-		p.SetState(100)
+		p.SetState(104)
 		p.AttrPath()
 	}
 
@@ -1897,6 +2241,10 @@ func (s *TypedDoubleContext) TypeAnnotation() ITypeAnnotationContext {
 	return t.(ITypeAnnotationContext)
 }
 
+func (s *TypedDoubleContext) MINUS() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserMINUS, 0)
+}
+
 func (s *TypedDoubleContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SCIMQueryListener); ok {
 		listenerT.EnterTypedDouble(s)
@@ -2017,6 +2365,10 @@ func (s *TypedIntegerContext) TypeAnnotation() ITypeAnnotationContext {
 	return t.(ITypeAnnotationContext)
 }
 
+func (s *TypedIntegerContext) MINUS() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserMINUS, 0)
+}
+
 func (s *TypedIntegerContext) EXP() antlr.TerminalNode {
 	return s.GetToken(SCIMQueryParserEXP, 0)
 }
@@ -2045,35 +2397,35 @@ func (s *TypedIntegerContext) Accept(visitor antlr.ParseTreeVisitor) interface{}
 
 func (p *SCIMQueryParser) TypedValue() (localctx ITypedValueContext) {
 	localctx = NewTypedValueContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 14, SCIMQueryParserRULE_typedValue)
+	p.EnterRule(localctx, 16, SCIMQueryParserRULE_typedValue)
 	var _la int
 
-	p.SetState(120)
+	p.SetState(127)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 16, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 17, p.GetParserRuleContext()) {
 	case 1:
 		localctx = NewTypedStringContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
-		p.SetState(103)
+		p.SetState(107)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
 
-		if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&4092) != 0 {
+		if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&524280) != 0 {
 			{
-				p.SetState(102)
+				p.SetState(106)
 				p.TypeAnnotation()
 			}
 
 		}
 		{
-			p.SetState(105)
+			p.SetState(109)
 			p.Match(SCIMQueryParserSTRING)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2084,22 +2436,40 @@ func (p *SCIMQueryParser) TypedValue() (localctx ITypedValueContext) {
 	case 2:
 		localctx = NewTypedDoubleContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
-		p.SetState(107)
+		p.SetState(111)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
 
-		if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&4092) != 0 {
+		if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&524280) != 0 {
 			{
-				p.SetState(106)
+				p.SetState(110)
 				p.TypeAnnotation()
 			}
 
+		}
+		p.SetState(114)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == SCIMQueryParserMINUS {
+			{
+				p.SetState(113)
+				p.Match(SCIMQueryParserMINUS)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
 		}
 		{
-			p.SetState(109)
+			p.SetState(116)
 			p.Match(SCIMQueryParserDOUBLE)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2110,31 +2480,31 @@ func (p *SCIMQueryParser) TypedValue() (localctx ITypedValueContext) {
 	case 3:
 		localctx = NewTypedIntegerContext(p, localctx)
 		p.EnterOuterAlt(localctx, 3)
-		p.SetState(111)
+		p.SetState(118)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
 
-		if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&4092) != 0 {
+		if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&524280) != 0 {
 			{
-				p.SetState(110)
+				p.SetState(117)
 				p.TypeAnnotation()
 			}
 
 		}
-		p.SetState(114)
+		p.SetState(121)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
 
-		if _la == SCIMQueryParserT__12 {
+		if _la == SCIMQueryParserMINUS {
 			{
-				p.SetState(113)
-				p.Match(SCIMQueryParserT__12)
+				p.SetState(120)
+				p.Match(SCIMQueryParserMINUS)
 				if p.HasError() {
 					// Recognition error - abort rule
 					goto errorExit
@@ -2143,19 +2513,19 @@ func (p *SCIMQueryParser) TypedValue() (localctx ITypedValueContext) {
 
 		}
 		{
-			p.SetState(116)
+			p.SetState(123)
 			p.Match(SCIMQueryParserINT)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		p.SetState(118)
+		p.SetState(125)
 		p.GetErrorHandler().Sync(p)
 
-		if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 15, p.GetParserRuleContext()) == 1 {
+		if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 16, p.GetParserRuleContext()) == 1 {
 			{
-				p.SetState(117)
+				p.SetState(124)
 				p.Match(SCIMQueryParserEXP)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -2328,6 +2698,22 @@ func (s *ListOfDoublesContext) ListDoubles() IListDoublesContext {
 	return t.(IListDoublesContext)
 }
 
+func (s *ListOfDoublesContext) TypeAnnotation() ITypeAnnotationContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ITypeAnnotationContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ITypeAnnotationContext)
+}
+
 func (s *ListOfDoublesContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SCIMQueryListener); ok {
 		listenerT.EnterListOfDoubles(s)
@@ -2384,6 +2770,22 @@ func (s *ListOfStringsContext) ListStrings() IListStringsContext {
 	return t.(IListStringsContext)
 }
 
+func (s *ListOfStringsContext) TypeAnnotation() ITypeAnnotationContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ITypeAnnotationContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ITypeAnnotationContext)
+}
+
 func (s *ListOfStringsContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(SCIMQueryListener); ok {
 		listenerT.EnterListOfStrings(s)
@@ -2406,12 +2808,12 @@ func (s *ListOfStringsContext) Accept(visitor antlr.ParseTreeVisitor) interface{
 	}
 }
 
-type BooleanContext struct {
+type LenValContext struct {
 	ValueContext
 }
 
-func NewBooleanContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *BooleanContext {
-	var p = new(BooleanContext)
+func NewLenValContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LenValContext {
+	var p = new(LenValContext)
 
 	InitEmptyValueContext(&p.ValueContext)
 	p.parser = parser
@@ -2420,25 +2822,93 @@ func NewBooleanContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *Boolea
 	return p
 }
 
-func (s *BooleanContext) GetRuleContext() antlr.RuleContext {
+func (s *LenValContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *BooleanContext) BOOLEAN() antlr.TerminalNode {
-	return s.GetToken(SCIMQueryParserBOOLEAN, 0)
+func (s *LenValContext) LEN() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserLEN, 0)
 }
 
-func (s *BooleanContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(SCIMQueryListener); ok {
-		listenerT.EnterBoolean(s)
-	}
+func (s *LenValContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserLPAREN, 0)
 }
 
-func (s *BooleanContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(SCIMQueryListener); ok {
-		listenerT.ExitBoolean(s)
-	}
-}
+func (s *LenValContext) AttrPath() IAttrPathContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IAttrPathContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAttrPathContext)
+}
+
+func (s *LenValContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserRPAREN, 0)
+}
+
+func (s *LenValContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.EnterLenVal(s)
+	}
+}
+
+func (s *LenValContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.ExitLenVal(s)
+	}
+}
+
+func (s *LenValContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SCIMQueryVisitor:
+		return t.VisitLenVal(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type BooleanContext struct {
+	ValueContext
+}
+
+func NewBooleanContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *BooleanContext {
+	var p = new(BooleanContext)
+
+	InitEmptyValueContext(&p.ValueContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ValueContext))
+
+	return p
+}
+
+func (s *BooleanContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *BooleanContext) BOOLEAN() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserBOOLEAN, 0)
+}
+
+func (s *BooleanContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.EnterBoolean(s)
+	}
+}
+
+func (s *BooleanContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.ExitBoolean(s)
+	}
+}
 
 func (s *BooleanContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
@@ -2494,6 +2964,357 @@ func (s *NullContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	}
 }
 
+type AttrValContext struct {
+	ValueContext
+}
+
+func NewAttrValContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AttrValContext {
+	var p = new(AttrValContext)
+
+	InitEmptyValueContext(&p.ValueContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ValueContext))
+
+	return p
+}
+
+func (s *AttrValContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AttrValContext) AttrPath() IAttrPathContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IAttrPathContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAttrPathContext)
+}
+
+func (s *AttrValContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.EnterAttrVal(s)
+	}
+}
+
+func (s *AttrValContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.ExitAttrVal(s)
+	}
+}
+
+func (s *AttrValContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SCIMQueryVisitor:
+		return t.VisitAttrVal(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type ArithmeticValContext struct {
+	ValueContext
+	op antlr.Token
+}
+
+func NewArithmeticValContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ArithmeticValContext {
+	var p = new(ArithmeticValContext)
+
+	InitEmptyValueContext(&p.ValueContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ValueContext))
+
+	return p
+}
+
+func (s *ArithmeticValContext) GetOp() antlr.Token { return s.op }
+
+func (s *ArithmeticValContext) SetOp(v antlr.Token) { s.op = v }
+
+func (s *ArithmeticValContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ArithmeticValContext) AllValue() []IValueContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IValueContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IValueContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IValueContext); ok {
+			tst[i] = t.(IValueContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ArithmeticValContext) Value(i int) IValueContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IValueContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IValueContext)
+}
+
+func (s *ArithmeticValContext) STAR() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserSTAR, 0)
+}
+
+func (s *ArithmeticValContext) SLASH() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserSLASH, 0)
+}
+
+func (s *ArithmeticValContext) PERCENT() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserPERCENT, 0)
+}
+
+func (s *ArithmeticValContext) PLUS() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserPLUS, 0)
+}
+
+func (s *ArithmeticValContext) MINUS() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserMINUS, 0)
+}
+
+func (s *ArithmeticValContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.EnterArithmeticVal(s)
+	}
+}
+
+func (s *ArithmeticValContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.ExitArithmeticVal(s)
+	}
+}
+
+func (s *ArithmeticValContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SCIMQueryVisitor:
+		return t.VisitArithmeticVal(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type DurationValContext struct {
+	ValueContext
+}
+
+func NewDurationValContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *DurationValContext {
+	var p = new(DurationValContext)
+
+	InitEmptyValueContext(&p.ValueContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ValueContext))
+
+	return p
+}
+
+func (s *DurationValContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *DurationValContext) DURATION() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserDURATION, 0)
+}
+
+func (s *DurationValContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.EnterDurationVal(s)
+	}
+}
+
+func (s *DurationValContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.ExitDurationVal(s)
+	}
+}
+
+func (s *DurationValContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SCIMQueryVisitor:
+		return t.VisitDurationVal(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type QuantifierValContext struct {
+	ValueContext
+	op antlr.Token
+}
+
+func NewQuantifierValContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *QuantifierValContext {
+	var p = new(QuantifierValContext)
+
+	InitEmptyValueContext(&p.ValueContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ValueContext))
+
+	return p
+}
+
+func (s *QuantifierValContext) GetOp() antlr.Token { return s.op }
+
+func (s *QuantifierValContext) SetOp(v antlr.Token) { s.op = v }
+
+func (s *QuantifierValContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *QuantifierValContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserLPAREN, 0)
+}
+
+func (s *QuantifierValContext) AttrPath() IAttrPathContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IAttrPathContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAttrPathContext)
+}
+
+func (s *QuantifierValContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserRPAREN, 0)
+}
+
+func (s *QuantifierValContext) ANY() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserANY, 0)
+}
+
+func (s *QuantifierValContext) ALL() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserALL, 0)
+}
+
+func (s *QuantifierValContext) NONE() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserNONE, 0)
+}
+
+func (s *QuantifierValContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.EnterQuantifierVal(s)
+	}
+}
+
+func (s *QuantifierValContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.ExitQuantifierVal(s)
+	}
+}
+
+func (s *QuantifierValContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SCIMQueryVisitor:
+		return t.VisitQuantifierVal(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type ParenValContext struct {
+	ValueContext
+}
+
+func NewParenValContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ParenValContext {
+	var p = new(ParenValContext)
+
+	InitEmptyValueContext(&p.ValueContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ValueContext))
+
+	return p
+}
+
+func (s *ParenValContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ParenValContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserLPAREN, 0)
+}
+
+func (s *ParenValContext) Value() IValueContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IValueContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IValueContext)
+}
+
+func (s *ParenValContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserRPAREN, 0)
+}
+
+func (s *ParenValContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.EnterParenVal(s)
+	}
+}
+
+func (s *ParenValContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.ExitParenVal(s)
+	}
+}
+
+func (s *ParenValContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SCIMQueryVisitor:
+		return t.VisitParenVal(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
 type ListOfIntsContext struct {
 	ValueContext
 }
@@ -2528,97 +3349,402 @@ func (s *ListOfIntsContext) ListInts() IListIntsContext {
 	return t.(IListIntsContext)
 }
 
-func (s *ListOfIntsContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(SCIMQueryListener); ok {
-		listenerT.EnterListOfInts(s)
-	}
-}
+func (s *ListOfIntsContext) TypeAnnotation() ITypeAnnotationContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ITypeAnnotationContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ITypeAnnotationContext)
+}
+
+func (s *ListOfIntsContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.EnterListOfInts(s)
+	}
+}
+
+func (s *ListOfIntsContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SCIMQueryListener); ok {
+		listenerT.ExitListOfInts(s)
+	}
+}
+
+func (s *ListOfIntsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case SCIMQueryVisitor:
+		return t.VisitListOfInts(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *SCIMQueryParser) Value() (localctx IValueContext) {
+	return p.value(0)
+}
+
+func (p *SCIMQueryParser) value(_p int) (localctx IValueContext) {
+	var _parentctx antlr.ParserRuleContext = p.GetParserRuleContext()
+
+	_parentState := p.GetState()
+	localctx = NewValueContext(p, p.GetParserRuleContext(), _parentState)
+	var _prevctx IValueContext = localctx
+	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
+	_startState := 18
+	p.EnterRecursionRule(localctx, 18, SCIMQueryParserRULE_value, _p)
+	var _la int
+
+	var _alt int
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(161)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 21, p.GetParserRuleContext()) {
+	case 1:
+		localctx = NewTypedValContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+
+		{
+			p.SetState(130)
+			p.TypedValue()
+		}
+
+	case 2:
+		localctx = NewBooleanContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(131)
+			p.Match(SCIMQueryParserBOOLEAN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case 3:
+		localctx = NewNullContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(132)
+			p.Match(SCIMQueryParserNULL)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case 4:
+		localctx = NewListOfIntsContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		p.SetState(134)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&524280) != 0 {
+			{
+				p.SetState(133)
+				p.TypeAnnotation()
+			}
+
+		}
+		{
+			p.SetState(136)
+			p.ListInts()
+		}
+
+	case 5:
+		localctx = NewListOfDoublesContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		p.SetState(138)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&524280) != 0 {
+			{
+				p.SetState(137)
+				p.TypeAnnotation()
+			}
+
+		}
+		{
+			p.SetState(140)
+			p.ListDoubles()
+		}
+
+	case 6:
+		localctx = NewListOfStringsContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		p.SetState(142)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&524280) != 0 {
+			{
+				p.SetState(141)
+				p.TypeAnnotation()
+			}
+
+		}
+		{
+			p.SetState(144)
+			p.ListStrings()
+		}
+
+	case 7:
+		localctx = NewDurationValContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(145)
+			p.Match(SCIMQueryParserDURATION)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case 8:
+		localctx = NewQuantifierValContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(146)
 
-func (s *ListOfIntsContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(SCIMQueryListener); ok {
-		listenerT.ExitListOfInts(s)
-	}
-}
+			var _lt = p.GetTokenStream().LT(1)
 
-func (s *ListOfIntsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
-	switch t := visitor.(type) {
-	case SCIMQueryVisitor:
-		return t.VisitListOfInts(s)
+			localctx.(*QuantifierValContext).op = _lt
 
-	default:
-		return t.VisitChildren(s)
-	}
-}
+			_la = p.GetTokenStream().LA(1)
 
-func (p *SCIMQueryParser) Value() (localctx IValueContext) {
-	localctx = NewValueContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 16, SCIMQueryParserRULE_value)
-	p.SetState(128)
-	p.GetErrorHandler().Sync(p)
-	if p.HasError() {
-		goto errorExit
-	}
+			if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&3848290697216) != 0) {
+				var _ri = p.GetErrorHandler().RecoverInline(p)
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 17, p.GetParserRuleContext()) {
-	case 1:
-		localctx = NewTypedValContext(p, localctx)
-		p.EnterOuterAlt(localctx, 1)
+				localctx.(*QuantifierValContext).op = _ri
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
 		{
-			p.SetState(122)
-			p.TypedValue()
+			p.SetState(147)
+			p.Match(SCIMQueryParserLPAREN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
 		}
-
-	case 2:
-		localctx = NewBooleanContext(p, localctx)
-		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(123)
-			p.Match(SCIMQueryParserBOOLEAN)
+			p.SetState(148)
+			p.AttrPath()
+		}
+		{
+			p.SetState(149)
+			p.Match(SCIMQueryParserRPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
 
-	case 3:
-		localctx = NewNullContext(p, localctx)
-		p.EnterOuterAlt(localctx, 3)
+	case 9:
+		localctx = NewLenValContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
 		{
-			p.SetState(124)
-			p.Match(SCIMQueryParserNULL)
+			p.SetState(151)
+			p.Match(SCIMQueryParserLEN)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-
-	case 4:
-		localctx = NewListOfIntsContext(p, localctx)
-		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(125)
-			p.ListInts()
+			p.SetState(152)
+			p.Match(SCIMQueryParserLPAREN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(153)
+			p.AttrPath()
+		}
+		{
+			p.SetState(154)
+			p.Match(SCIMQueryParserRPAREN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
 		}
 
-	case 5:
-		localctx = NewListOfDoublesContext(p, localctx)
-		p.EnterOuterAlt(localctx, 5)
+	case 10:
+		localctx = NewAttrValContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
 		{
-			p.SetState(126)
-			p.ListDoubles()
+			p.SetState(156)
+			p.AttrPath()
 		}
 
-	case 6:
-		localctx = NewListOfStringsContext(p, localctx)
-		p.EnterOuterAlt(localctx, 6)
+	case 11:
+		localctx = NewParenValContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
 		{
-			p.SetState(127)
-			p.ListStrings()
+			p.SetState(157)
+			p.Match(SCIMQueryParserLPAREN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(158)
+			p.value(0)
+		}
+		{
+			p.SetState(159)
+			p.Match(SCIMQueryParserRPAREN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
 		}
 
 	case antlr.ATNInvalidAltNumber:
 		goto errorExit
 	}
+	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
+	p.SetState(171)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 23, p.GetParserRuleContext())
+	if p.HasError() {
+		goto errorExit
+	}
+	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+		if _alt == 1 {
+			if p.GetParseListeners() != nil {
+				p.TriggerExitRuleEvent()
+			}
+			_prevctx = localctx
+			p.SetState(169)
+			p.GetErrorHandler().Sync(p)
+			if p.HasError() {
+				goto errorExit
+			}
+
+			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 22, p.GetParserRuleContext()) {
+			case 1:
+				localctx = NewArithmeticValContext(p, NewValueContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, SCIMQueryParserRULE_value)
+				p.SetState(163)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 2)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(164)
+
+					var _lt = p.GetTokenStream().LT(1)
+
+					localctx.(*ArithmeticValContext).op = _lt
+
+					_la = p.GetTokenStream().LA(1)
+
+					if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&1970324836974592) != 0) {
+						var _ri = p.GetErrorHandler().RecoverInline(p)
+
+						localctx.(*ArithmeticValContext).op = _ri
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(165)
+					p.value(3)
+				}
+
+			case 2:
+				localctx = NewArithmeticValContext(p, NewValueContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, SCIMQueryParserRULE_value)
+				p.SetState(166)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 1)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 1)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(167)
+
+					var _lt = p.GetTokenStream().LT(1)
+
+					localctx.(*ArithmeticValContext).op = _lt
+
+					_la = p.GetTokenStream().LA(1)
+
+					if !(_la == SCIMQueryParserPLUS || _la == SCIMQueryParserMINUS) {
+						var _ri = p.GetErrorHandler().RecoverInline(p)
+
+						localctx.(*ArithmeticValContext).op = _ri
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(168)
+					p.value(2)
+				}
+
+			case antlr.ATNInvalidAltNumber:
+				goto errorExit
+			}
+
+		}
+		p.SetState(173)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 23, p.GetParserRuleContext())
+		if p.HasError() {
+			goto errorExit
+		}
+	}
 
 errorExit:
 	if p.HasError() {
@@ -2628,7 +3754,7 @@ errorExit:
 		p.GetErrorHandler().Recover(p, v)
 		p.SetError(nil)
 	}
-	p.ExitRule()
+	p.UnrollRecursionContexts(_parentctx)
 	return localctx
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
@@ -2727,18 +3853,18 @@ func (s *ListStringsContext) Accept(visitor antlr.ParseTreeVisitor) interface{}
 
 func (p *SCIMQueryParser) ListStrings() (localctx IListStringsContext) {
 	localctx = NewListStringsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, SCIMQueryParserRULE_listStrings)
+	p.EnterRule(localctx, 20, SCIMQueryParserRULE_listStrings)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(130)
-		p.Match(SCIMQueryParserT__13)
+		p.SetState(174)
+		p.Match(SCIMQueryParserT__0)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
 	{
-		p.SetState(131)
+		p.SetState(175)
 		p.SubListOfStrings()
 	}
 
@@ -2859,18 +3985,18 @@ func (s *SubListOfStringsContext) Accept(visitor antlr.ParseTreeVisitor) interfa
 
 func (p *SCIMQueryParser) SubListOfStrings() (localctx ISubListOfStringsContext) {
 	localctx = NewSubListOfStringsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, SCIMQueryParserRULE_subListOfStrings)
-	p.SetState(138)
+	p.EnterRule(localctx, 22, SCIMQueryParserRULE_subListOfStrings)
+	p.SetState(182)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 18, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 24, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(133)
+			p.SetState(177)
 			p.Match(SCIMQueryParserSTRING)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2878,7 +4004,7 @@ func (p *SCIMQueryParser) SubListOfStrings() (localctx ISubListOfStringsContext)
 			}
 		}
 		{
-			p.SetState(134)
+			p.SetState(178)
 			p.Match(SCIMQueryParserCOMMA)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2886,14 +4012,14 @@ func (p *SCIMQueryParser) SubListOfStrings() (localctx ISubListOfStringsContext)
 			}
 		}
 		{
-			p.SetState(135)
+			p.SetState(179)
 			p.SubListOfStrings()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(136)
+			p.SetState(180)
 			p.Match(SCIMQueryParserSTRING)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2901,8 +4027,8 @@ func (p *SCIMQueryParser) SubListOfStrings() (localctx ISubListOfStringsContext)
 			}
 		}
 		{
-			p.SetState(137)
-			p.Match(SCIMQueryParserT__14)
+			p.SetState(181)
+			p.Match(SCIMQueryParserT__1)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
@@ -3020,18 +4146,18 @@ func (s *ListDoublesContext) Accept(visitor antlr.ParseTreeVisitor) interface{}
 
 func (p *SCIMQueryParser) ListDoubles() (localctx IListDoublesContext) {
 	localctx = NewListDoublesContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, SCIMQueryParserRULE_listDoubles)
+	p.EnterRule(localctx, 24, SCIMQueryParserRULE_listDoubles)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(140)
-		p.Match(SCIMQueryParserT__13)
+		p.SetState(184)
+		p.Match(SCIMQueryParserT__0)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
 	{
-		p.SetState(141)
+		p.SetState(185)
 		p.SubListOfDoubles()
 	}
 
@@ -3059,6 +4185,7 @@ type ISubListOfDoublesContext interface {
 	DOUBLE() antlr.TerminalNode
 	COMMA() antlr.TerminalNode
 	SubListOfDoubles() ISubListOfDoublesContext
+	MINUS() antlr.TerminalNode
 
 	// IsSubListOfDoublesContext differentiates from other interfaces.
 	IsSubListOfDoublesContext()
@@ -3120,6 +4247,10 @@ func (s *SubListOfDoublesContext) SubListOfDoubles() ISubListOfDoublesContext {
 	return t.(ISubListOfDoublesContext)
 }
 
+func (s *SubListOfDoublesContext) MINUS() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserMINUS, 0)
+}
+
 func (s *SubListOfDoublesContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -3152,18 +4283,38 @@ func (s *SubListOfDoublesContext) Accept(visitor antlr.ParseTreeVisitor) interfa
 
 func (p *SCIMQueryParser) SubListOfDoubles() (localctx ISubListOfDoublesContext) {
 	localctx = NewSubListOfDoublesContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 24, SCIMQueryParserRULE_subListOfDoubles)
-	p.SetState(148)
+	p.EnterRule(localctx, 26, SCIMQueryParserRULE_subListOfDoubles)
+	var _la int
+
+	p.SetState(198)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 19, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 27, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
+		p.SetState(188)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == SCIMQueryParserMINUS {
+			{
+				p.SetState(187)
+				p.Match(SCIMQueryParserMINUS)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
 		{
-			p.SetState(143)
+			p.SetState(190)
 			p.Match(SCIMQueryParserDOUBLE)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3171,7 +4322,7 @@ func (p *SCIMQueryParser) SubListOfDoubles() (localctx ISubListOfDoublesContext)
 			}
 		}
 		{
-			p.SetState(144)
+			p.SetState(191)
 			p.Match(SCIMQueryParserCOMMA)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3179,14 +4330,32 @@ func (p *SCIMQueryParser) SubListOfDoubles() (localctx ISubListOfDoublesContext)
 			}
 		}
 		{
-			p.SetState(145)
+			p.SetState(192)
 			p.SubListOfDoubles()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
+		p.SetState(194)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == SCIMQueryParserMINUS {
+			{
+				p.SetState(193)
+				p.Match(SCIMQueryParserMINUS)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
 		{
-			p.SetState(146)
+			p.SetState(196)
 			p.Match(SCIMQueryParserDOUBLE)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3194,8 +4363,8 @@ func (p *SCIMQueryParser) SubListOfDoubles() (localctx ISubListOfDoublesContext)
 			}
 		}
 		{
-			p.SetState(147)
-			p.Match(SCIMQueryParserT__14)
+			p.SetState(197)
+			p.Match(SCIMQueryParserT__1)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
@@ -3313,18 +4482,18 @@ func (s *ListIntsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *SCIMQueryParser) ListInts() (localctx IListIntsContext) {
 	localctx = NewListIntsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 26, SCIMQueryParserRULE_listInts)
+	p.EnterRule(localctx, 28, SCIMQueryParserRULE_listInts)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(150)
-		p.Match(SCIMQueryParserT__13)
+		p.SetState(200)
+		p.Match(SCIMQueryParserT__0)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
 	{
-		p.SetState(151)
+		p.SetState(201)
 		p.SubListOfInts()
 	}
 
@@ -3352,6 +4521,7 @@ type ISubListOfIntsContext interface {
 	INT() antlr.TerminalNode
 	COMMA() antlr.TerminalNode
 	SubListOfInts() ISubListOfIntsContext
+	MINUS() antlr.TerminalNode
 
 	// IsSubListOfIntsContext differentiates from other interfaces.
 	IsSubListOfIntsContext()
@@ -3413,6 +4583,10 @@ func (s *SubListOfIntsContext) SubListOfInts() ISubListOfIntsContext {
 	return t.(ISubListOfIntsContext)
 }
 
+func (s *SubListOfIntsContext) MINUS() antlr.TerminalNode {
+	return s.GetToken(SCIMQueryParserMINUS, 0)
+}
+
 func (s *SubListOfIntsContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -3445,18 +4619,38 @@ func (s *SubListOfIntsContext) Accept(visitor antlr.ParseTreeVisitor) interface{
 
 func (p *SCIMQueryParser) SubListOfInts() (localctx ISubListOfIntsContext) {
 	localctx = NewSubListOfIntsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 28, SCIMQueryParserRULE_subListOfInts)
-	p.SetState(158)
+	p.EnterRule(localctx, 30, SCIMQueryParserRULE_subListOfInts)
+	var _la int
+
+	p.SetState(214)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 20, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 30, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
+		p.SetState(204)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == SCIMQueryParserMINUS {
+			{
+				p.SetState(203)
+				p.Match(SCIMQueryParserMINUS)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
 		{
-			p.SetState(153)
+			p.SetState(206)
 			p.Match(SCIMQueryParserINT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3464,7 +4658,7 @@ func (p *SCIMQueryParser) SubListOfInts() (localctx ISubListOfIntsContext) {
 			}
 		}
 		{
-			p.SetState(154)
+			p.SetState(207)
 			p.Match(SCIMQueryParserCOMMA)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3472,14 +4666,32 @@ func (p *SCIMQueryParser) SubListOfInts() (localctx ISubListOfIntsContext) {
 			}
 		}
 		{
-			p.SetState(155)
+			p.SetState(208)
 			p.SubListOfInts()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
+		p.SetState(210)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == SCIMQueryParserMINUS {
+			{
+				p.SetState(209)
+				p.Match(SCIMQueryParserMINUS)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
 		{
-			p.SetState(156)
+			p.SetState(212)
 			p.Match(SCIMQueryParserINT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3487,8 +4699,8 @@ func (p *SCIMQueryParser) SubListOfInts() (localctx ISubListOfIntsContext) {
 			}
 		}
 		{
-			p.SetState(157)
-			p.Match(SCIMQueryParserT__14)
+			p.SetState(213)
+			p.Match(SCIMQueryParserT__1)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
@@ -3521,6 +4733,13 @@ func (p *SCIMQueryParser) Sempred(localctx antlr.RuleContext, ruleIndex, predInd
 		}
 		return p.Query_Sempred(t, predIndex)
 
+	case 9:
+		var t *ValueContext = nil
+		if localctx != nil {
+			t = localctx.(*ValueContext)
+		}
+		return p.Value_Sempred(t, predIndex)
+
 	default:
 		panic("No predicate with index: " + fmt.Sprint(ruleIndex))
 	}
@@ -3529,7 +4748,23 @@ func (p *SCIMQueryParser) Sempred(localctx antlr.RuleContext, ruleIndex, predInd
 func (p *SCIMQueryParser) Query_Sempred(localctx antlr.RuleContext, predIndex int) bool {
 	switch predIndex {
 	case 0:
-		return p.Precpred(p.GetParserRuleContext(), 3)
+		return p.Precpred(p.GetParserRuleContext(), 5)
+
+	case 1:
+		return p.Precpred(p.GetParserRuleContext(), 4)
+
+	default:
+		panic("No predicate with index: " + fmt.Sprint(predIndex))
+	}
+}
+
+func (p *SCIMQueryParser) Value_Sempred(localctx antlr.RuleContext, predIndex int) bool {
+	switch predIndex {
+	case 2:
+		return p.Precpred(p.GetParserRuleContext(), 2)
+
+	case 3:
+		return p.Precpred(p.GetParserRuleContext(), 1)
 
 	default:
 		panic("No predicate with index: " + fmt.Sprint(predIndex))
@@ -20,12 +20,18 @@ type SCIMQueryVisitor interface {
 	// Visit a parse tree produced by SCIMQueryParser#presentExp.
 	VisitPresentExp(ctx *PresentExpContext) interface{}
 
+	// Visit a parse tree produced by SCIMQueryParser#valuePathExp.
+	VisitValuePathExp(ctx *ValuePathExpContext) interface{}
+
 	// Visit a parse tree produced by SCIMQueryParser#logicalExp.
 	VisitLogicalExp(ctx *LogicalExpContext) interface{}
 
 	// Visit a parse tree produced by SCIMQueryParser#attrPath.
 	VisitAttrPath(ctx *AttrPathContext) interface{}
 
+	// Visit a parse tree produced by SCIMQueryParser#attrName.
+	VisitAttrName(ctx *AttrNameContext) interface{}
+
 	// Visit a parse tree produced by SCIMQueryParser#typeAnnotation.
 	VisitTypeAnnotation(ctx *TypeAnnotationContext) interface{}
 
@@ -50,20 +56,38 @@ type SCIMQueryVisitor interface {
 	// Visit a parse tree produced by SCIMQueryParser#typedVal.
 	VisitTypedVal(ctx *TypedValContext) interface{}
 
+	// Visit a parse tree produced by SCIMQueryParser#listOfDoubles.
+	VisitListOfDoubles(ctx *ListOfDoublesContext) interface{}
+
+	// Visit a parse tree produced by SCIMQueryParser#listOfStrings.
+	VisitListOfStrings(ctx *ListOfStringsContext) interface{}
+
+	// Visit a parse tree produced by SCIMQueryParser#lenVal.
+	VisitLenVal(ctx *LenValContext) interface{}
+
 	// Visit a parse tree produced by SCIMQueryParser#boolean.
 	VisitBoolean(ctx *BooleanContext) interface{}
 
 	// Visit a parse tree produced by SCIMQueryParser#null.
 	VisitNull(ctx *NullContext) interface{}
 
-	// Visit a parse tree produced by SCIMQueryParser#listOfInts.
-	VisitListOfInts(ctx *ListOfIntsContext) interface{}
+	// Visit a parse tree produced by SCIMQueryParser#attrVal.
+	VisitAttrVal(ctx *AttrValContext) interface{}
 
-	// Visit a parse tree produced by SCIMQueryParser#listOfDoubles.
-	VisitListOfDoubles(ctx *ListOfDoublesContext) interface{}
+	// Visit a parse tree produced by SCIMQueryParser#arithmeticVal.
+	VisitArithmeticVal(ctx *ArithmeticValContext) interface{}
 
-	// Visit a parse tree produced by SCIMQueryParser#listOfStrings.
-	VisitListOfStrings(ctx *ListOfStringsContext) interface{}
+	// Visit a parse tree produced by SCIMQueryParser#durationVal.
+	VisitDurationVal(ctx *DurationValContext) interface{}
+
+	// Visit a parse tree produced by SCIMQueryParser#quantifierVal.
+	VisitQuantifierVal(ctx *QuantifierValContext) interface{}
+
+	// Visit a parse tree produced by SCIMQueryParser#parenVal.
+	VisitParenVal(ctx *ParenValContext) interface{}
+
+	// Visit a parse tree produced by SCIMQueryParser#listOfInts.
+	VisitListOfInts(ctx *ListOfIntsContext) interface{}
 
 	// Visit a parse tree produced by SCIMQueryParser#listStrings.
 	VisitListStrings(ctx *ListStringsContext) interface{}
@@ -0,0 +1,536 @@
+package rule
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+)
+
+// FromJSONLogic builds a Rule from a JSON Logic expression (https://jsonlogic.com), such as the
+// output of a frontend rule builder, e.g.
+//
+//	ruleSet, err := rule.FromJSONLogic([]byte(`{"and": [{">=": [{"var": "age"}, 18]}, {"in": [{"var": "lang"}, ["en", "fr"]]}]}`))
+//
+// is the Rule of `age ge 18 and lang in ["en", "fr"]`. The supported operations are the ones both
+// languages share:
+//   - "and", "or" and "!" become and, or and not
+//   - "==", "!=", ">", ">=", "<" and "<=" become eq, ne, gt, ge, lt and le ("===" and "!==" are
+//     treated like "==" and "!="), with a var on either side; "<" and "<=" with three operands
+//     (between) become two comparisons joined by and
+//   - comparisons with null check for presence: "!=" becomes pr and "==" becomes not pr
+//   - "in" with a var and a list (or a string) becomes in; "in" with a string and a var becomes co
+//   - "var" paths become attribute names, e.g. "address.city"
+//
+// Numbers become int64 if they are integers, and float64 otherwise; lists must hold only strings or
+// only numbers. The Rule is evaluated with the semantics of this package, e.g. a missing attribute
+// never matches a comparison. Other operations, a var with a default value or on its own (testing
+// truthiness), and paths that are not attribute names fail with ErrorInvalidJSONLogic.
+func FromJSONLogic(data []byte) (Rule, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var logic any
+	if err := dec.Decode(&logic); err != nil {
+		return Rule{}, newErrorInvalidJSONLogic(err.Error())
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return Rule{}, newErrorInvalidJSONLogic("unexpected data after the expression")
+	}
+
+	b := &jsonLogicBuilder{}
+	expr, err := b.condition(logic)
+	if err != nil {
+		return Rule{}, err
+	}
+	return Rule{
+		exprTree: *expr,
+		Params:   b.parameters,
+	}, nil
+}
+
+// ToJSONLogic translates the Rule into a JSON Logic expression, the inverse of FromJSONLogic.
+// "pr" becomes a comparison with null ("!=", or "==" when negated), co an "in" with the string
+// first, and nin a negated "in". Numbers lose their type annotations.
+//
+// sw, ew, mr, wi, case-insensitive attributes (Config.CaseInsensitive), function calls, value paths,
+// quantifiers, len, now() and arithmetic, and [d], [t], [dur], [ip], [cidr] and [semver] values fail
+// with ErrorUntranslatable.
+func (g *Rule) ToJSONLogic() ([]byte, error) {
+	logic, err := jsonLogic(&g.exprTree)
+	if err != nil {
+		return nil, err
+	}
+	return marshalJSON(logic)
+}
+
+// attrPathPattern matches the attribute names of the query language, e.g. "address.city".
+var attrPathPattern = regexp.MustCompile(`^[A-Za-z][-_:0-9A-Za-z]*(\.[A-Za-z][-_:0-9A-Za-z]*)*$`)
+
+// jsonLogicOperators maps the comparisons of JSON Logic to operators.
+var jsonLogicOperators = map[string]string{
+	"==":  "eq",
+	"===": "eq",
+	"!=":  "ne",
+	"!==": "ne",
+	">":   "gt",
+	">=":  "ge",
+	"<":   "lt",
+	"<=":  "le",
+}
+
+// jsonLogicFlipped maps an operator to the one that gives the same result with its operands swapped,
+// e.g. 18 < age is age gt 18.
+var jsonLogicFlipped = map[string]string{
+	"eq": "eq",
+	"ne": "ne",
+	"gt": "lt",
+	"lt": "gt",
+	"ge": "le",
+	"le": "ge",
+}
+
+// jsonLogicBuilder collects the Parameters of FromJSONLogic, like queryVisitor does for ParseQuery.
+type jsonLogicBuilder struct {
+	parameters []Parameter
+}
+
+// condition translates a JSON Logic expression that yields a boolean into an exprTree.
+func (b *jsonLogicBuilder) condition(logic any) (*exprTree, error) {
+	op, args, err := jsonLogicOperation(logic)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "and", "or":
+		if len(args) == 0 {
+			return nil, newErrorInvalidJSONLogic(fmt.Sprintf("%q needs at least one operand", op))
+		}
+		var tree *exprTree
+		for i, arg := range args {
+			node, err := b.condition(arg)
+			if err != nil {
+				return nil, err
+			}
+			if i == 0 {
+				tree = node
+				continue
+			}
+			tree = &exprTree{op: op, left: tree, right: node}
+		}
+		return tree, nil
+
+	case "!":
+		if len(args) != 1 {
+			return nil, newErrorInvalidJSONLogic(fmt.Sprintf(`"!" needs one operand, not %d`, len(args)))
+		}
+		node, err := b.condition(args[0])
+		if err != nil {
+			return nil, err
+		}
+		node.not = !node.not
+		return node, nil
+
+	case "in":
+		if len(args) != 2 {
+			return nil, newErrorInvalidJSONLogic(fmt.Sprintf(`"in" needs two operands, not %d`, len(args)))
+		}
+		return b.membership(args[0], args[1])
+
+	case "var":
+		return nil, newErrorInvalidJSONLogic(fmt.Sprintf("%s tests truthiness, which has no rule equivalent; compare it instead", jsonText(logic)))
+	}
+
+	operator, ok := jsonLogicOperators[op]
+	if !ok {
+		return nil, newErrorInvalidJSONLogic(fmt.Sprintf("the operation %q has no rule equivalent", op))
+	}
+	if len(args) == 3 && (operator == "lt" || operator == "le") {
+		// Between: {"<": [1, {"var": "x"}, 10]} is 1 < x and x < 10
+		left, err := b.comparison(operator, args[0], args[1])
+		if err != nil {
+			return nil, err
+		}
+		right, err := b.comparison(operator, args[1], args[2])
+		if err != nil {
+			return nil, err
+		}
+		return &exprTree{op: "and", left: left, right: right}, nil
+	}
+	if len(args) != 2 {
+		return nil, newErrorInvalidJSONLogic(fmt.Sprintf("%q needs two operands, not %d", op, len(args)))
+	}
+	return b.comparison(operator, args[0], args[1])
+}
+
+// comparison translates a comparison of left and right, one of which must be a var.
+func (b *jsonLogicBuilder) comparison(operator string, left, right any) (*exprTree, error) {
+	name, isVar, err := jsonLogicVar(left)
+	if err != nil {
+		return nil, err
+	}
+	if !isVar {
+		// 18 < age is age gt 18
+		if name, isVar, err = jsonLogicVar(right); err != nil {
+			return nil, err
+		}
+		if !isVar {
+			return nil, newErrorInvalidJSONLogic(fmt.Sprintf("the comparison of %s and %s needs a var on one side", jsonText(left), jsonText(right)))
+		}
+		operator, left, right = jsonLogicFlipped[operator], right, left
+	}
+
+	if right == nil {
+		switch operator {
+		case "ne":
+			return b.present(name, false), nil
+		case "eq":
+			return b.present(name, true), nil
+		}
+		return nil, newErrorInvalidJSONLogic(fmt.Sprintf("the comparison of %s with null has no rule equivalent", jsonText(left)))
+	}
+	if _, ok := right.([]any); ok {
+		return nil, newErrorInvalidJSONLogic(fmt.Sprintf("the list %s is only allowed as the second operand of \"in\"", jsonText(right)))
+	}
+	return b.leaf(name, operator, right)
+}
+
+// membership translates "in", which JSON Logic uses both for list membership and for substrings.
+func (b *jsonLogicBuilder) membership(needle, haystack any) (*exprTree, error) {
+	name, isVar, err := jsonLogicVar(needle)
+	if err != nil {
+		return nil, err
+	}
+	if isVar {
+		switch haystack.(type) {
+		case []any, string, map[string]any:
+			return b.leaf(name, "in", haystack)
+		}
+		return nil, newErrorInvalidJSONLogic(fmt.Sprintf(`"in" needs a list or a string after %s, not %s`, jsonText(needle), jsonText(haystack)))
+	}
+
+	// {"in": ["@example.com", {"var": "email"}]} is email co "@example.com"
+	s, ok := needle.(string)
+	if !ok {
+		return nil, newErrorInvalidJSONLogic(fmt.Sprintf(`"in" needs a var or a string as its first operand, not %s`, jsonText(needle)))
+	}
+	if name, isVar, err = jsonLogicVar(haystack); err != nil {
+		return nil, err
+	}
+	if !isVar {
+		return nil, newErrorInvalidJSONLogic(fmt.Sprintf(`"in" needs a var after the string %q, not %s`, s, jsonText(haystack)))
+	}
+	return b.leaf(name, "co", s)
+}
+
+// present adds a "pr" Parameter for the attribute name, negated if not is set.
+func (b *jsonLogicBuilder) present(name string, not bool) *exprTree {
+	p := Parameter{
+		id:        len(b.parameters),
+		Name:      name,
+		InputType: Expression,
+		operator:  "pr",
+	}
+	b.parameters = append(b.parameters, p)
+	return &exprTree{not: not, param: &p}
+}
+
+// leaf adds the Parameter of the comparison name operator value. A var as the value becomes a
+// paramRef to a Parameter of its own, added after the one of the comparison as in ParseQuery.
+func (b *jsonLogicBuilder) leaf(name string, operator string, value any) (*exprTree, error) {
+	var ref *Parameter
+	var val any
+	var valType ArgumentType
+	strict := false
+
+	switch x := value.(type) {
+	case map[string]any:
+		refName, isVar, err := jsonLogicVar(x)
+		if err != nil {
+			return nil, err
+		}
+		if !isVar {
+			return nil, newErrorInvalidJSONLogic(fmt.Sprintf("%s is neither a var nor a constant", jsonText(x)))
+		}
+		ref = &Parameter{Name: refName, InputType: Expression}
+		val, valType = paramRef{param: ref}, ArgTypeUnknown
+	case []any:
+		list, err := jsonLogicList(x)
+		if err != nil {
+			return nil, err
+		}
+		val, valType = list, ArgTypeList
+	case json.Number:
+		var err error
+		if val, valType, err = jsonLogicNumber(x); err != nil {
+			return nil, err
+		}
+	case string:
+		val, valType = x, ArgTypeString
+	case bool:
+		val, valType, strict = x, ArgTypeBoolean, true
+	default:
+		return nil, newErrorInvalidJSONLogic(fmt.Sprintf("the value %s has no rule equivalent", jsonText(value)))
+	}
+
+	p := Parameter{
+		id:              len(b.parameters),
+		Name:            name,
+		operator:        operator,
+		compareValue:    val,
+		InputType:       Expression,
+		Expression:      valType,
+		strictTypeCheck: strict,
+	}
+	b.parameters = append(b.parameters, p)
+	if ref != nil {
+		ref.id = len(b.parameters)
+		b.parameters = append(b.parameters, *ref)
+	}
+	return &exprTree{param: &p}, nil
+}
+
+// jsonLogicOperation splits a JSON Logic operation such as {">": [{"var": "age"}, 18]} into its
+// operator and arguments. A single argument may be given without the array, as in {"!": {...}}.
+func jsonLogicOperation(logic any) (string, []any, error) {
+	m, ok := logic.(map[string]any)
+	if !ok || len(m) != 1 {
+		return "", nil, newErrorInvalidJSONLogic(fmt.Sprintf("%s is not an operation", jsonText(logic)))
+	}
+	for op, arg := range m {
+		if args, ok := arg.([]any); ok {
+			return op, args, nil
+		}
+		return op, []any{arg}, nil
+	}
+	return "", nil, nil
+}
+
+// jsonLogicVar returns the attribute name of a var operation such as {"var": "address.city"}. The
+// second return value is false if logic is not a var.
+func jsonLogicVar(logic any) (string, bool, error) {
+	m, ok := logic.(map[string]any)
+	if !ok || len(m) != 1 {
+		return "", false, nil
+	}
+	path, ok := m["var"]
+	if !ok {
+		return "", false, nil
+	}
+	if args, ok := path.([]any); ok {
+		if len(args) != 1 {
+			return "", false, newErrorInvalidJSONLogic(fmt.Sprintf("%s has a default value, which has no rule equivalent", jsonText(logic)))
+		}
+		path = args[0]
+	}
+	name, ok := path.(string)
+	if !ok || !attrPathPattern.MatchString(name) {
+		return "", false, newErrorInvalidJSONLogic(fmt.Sprintf("the path of %s is not an attribute name", jsonText(logic)))
+	}
+	return name, true, nil
+}
+
+// jsonLogicNumber converts a JSON number to an int64 (or a uint64 beyond its range) for integers,
+// and to a float64 otherwise, like the integer and decimal literals of a query.
+func jsonLogicNumber(n json.Number) (any, ArgumentType, error) {
+	if i, err := n.Int64(); err == nil {
+		return i, ArgTypeInteger64, nil
+	}
+	if u, err := strconv.ParseUint(n.String(), 10, 64); err == nil {
+		return u, ArgTypeUnsignedInteger64, nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return nil, ArgTypeUnknown, newErrorInvalidJSONLogic(fmt.Sprintf("the number %s is out of range", n))
+	}
+	return f, ArgTypeFloat64, nil
+}
+
+// jsonLogicList converts a JSON array of strings or numbers to a []string, []int64 or []float64,
+// the types of the list literals of a query.
+func jsonLogicList(items []any) (any, error) {
+	if len(items) == 0 {
+		return nil, newErrorInvalidJSONLogic("an empty list has no rule equivalent")
+	}
+	if _, ok := items[0].(string); ok {
+		strs := make([]string, len(items))
+		for i, item := range items {
+			if strs[i], ok = item.(string); !ok {
+				return nil, newErrorInvalidJSONLogic(fmt.Sprintf("the list %s mixes strings with other values", jsonText(items)))
+			}
+		}
+		return strs, nil
+	}
+
+	ints := make([]int64, len(items))
+	floats := make([]float64, len(items))
+	integral := true
+	for i, item := range items {
+		n, ok := item.(json.Number)
+		if !ok {
+			return nil, newErrorInvalidJSONLogic(fmt.Sprintf("the list %s must hold only strings or only numbers", jsonText(items)))
+		}
+		var err error
+		if integral {
+			ints[i], err = n.Int64()
+			integral = err == nil
+		}
+		if floats[i], err = n.Float64(); err != nil {
+			return nil, newErrorInvalidJSONLogic(fmt.Sprintf("the number %s is out of range", n))
+		}
+	}
+	if integral {
+		return ints, nil
+	}
+	return floats, nil
+}
+
+// jsonText returns the JSON encoding of a decoded JSON value, for error messages.
+func jsonText(v any) string {
+	text, err := marshalJSON(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(text)
+}
+
+// marshalJSON is like json.Marshal, but keeps <, > and & as they are, so that comparisons
+// read as {">": [...]} rather than {"\u003e": [...]}.
+func marshalJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// jsonLogic translates an exprTree node into a JSON Logic operation for ToJSONLogic.
+func jsonLogic(e *exprTree) (any, error) {
+	if e.op == "" {
+		if e.param == nil {
+			return nil, ErrorNoExpression
+		}
+		if e.not && e.param.operator == "pr" {
+			return map[string]any{"==": []any{map[string]any{"var": e.param.Name}, nil}}, nil
+		}
+		logic, err := jsonLogicComparison(e.param)
+		if err != nil {
+			return nil, err
+		}
+		if e.not {
+			return map[string]any{"!": []any{logic}}, nil
+		}
+		return logic, nil
+	}
+
+	var operands []any
+	for _, child := range []*exprTree{e.left, e.right} {
+		sub, err := jsonLogic(child)
+		if err != nil {
+			return nil, err
+		}
+		// a and (b and c) is a single "and" of a, b and c
+		if m, ok := sub.(map[string]any); ok && len(m) == 1 {
+			if nested, ok := m[e.op].([]any); ok {
+				operands = append(operands, nested...)
+				continue
+			}
+		}
+		operands = append(operands, sub)
+	}
+	var logic any = map[string]any{e.op: operands}
+	if e.not {
+		logic = map[string]any{"!": []any{logic}}
+	}
+	return logic, nil
+}
+
+// jsonLogicComparisons maps operators to the comparisons of JSON Logic.
+var jsonLogicComparisons = map[string]string{
+	"eq": "==",
+	"ne": "!=",
+	"gt": ">",
+	"ge": ">=",
+	"lt": "<",
+	"le": "<=",
+}
+
+// jsonLogicComparison translates the leaf comparison of p into a JSON Logic operation.
+func jsonLogicComparison(p *Parameter) (any, error) {
+	switch {
+	case p.leftExpr != nil:
+		return nil, newErrorUntranslatable("JSON Logic", fmt.Sprintf("the computed value %s has no JSON Logic equivalent", p.Name))
+	case p.InputType == FunctionCall:
+		return nil, newErrorUntranslatable("JSON Logic", fmt.Sprintf("the function %s has no JSON Logic equivalent", p.Name))
+	case p.Filter != nil:
+		return nil, newErrorUntranslatable("JSON Logic", fmt.Sprintf("the value path %s[...] has no JSON Logic equivalent", p.Name))
+	case p.quantifier != "":
+		return nil, newErrorUntranslatable("JSON Logic", fmt.Sprintf("the quantifier %s(%s) has no JSON Logic equivalent", p.quantifier, p.Name))
+	case p.caseInsensitive && foldsCase(p.operator):
+		return nil, newErrorUntranslatable("JSON Logic", fmt.Sprintf("case-insensitive comparisons of %s have no JSON Logic equivalent", p.Name))
+	}
+
+	attr := map[string]any{"var": p.Name}
+	op, ok := jsonLogicComparisons[p.operator]
+	switch p.operator {
+	case "pr":
+		return map[string]any{"!=": []any{attr, nil}}, nil
+	case "in", "nin", "co":
+	default:
+		if !ok {
+			return nil, newErrorUntranslatable("JSON Logic", fmt.Sprintf("the operator %s has no JSON Logic equivalent", p.operator))
+		}
+	}
+	value, err := jsonLogicValue(p.compareValue)
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.operator {
+	case "in":
+		return map[string]any{"in": []any{attr, value}}, nil
+	case "nin":
+		return map[string]any{"!": []any{map[string]any{"in": []any{attr, value}}}}, nil
+	case "co":
+		if _, ok := value.(string); !ok {
+			return nil, newErrorUntranslatable("JSON Logic", "co needs a string value")
+		}
+		return map[string]any{"in": []any{value, attr}}, nil
+	}
+	return map[string]any{op: []any{attr, value}}, nil
+}
+
+// jsonLogicValue converts a comparison value for ToJSONLogic. Attributes on the right-hand side
+// become vars; strings, numbers, booleans, null and lists of them are kept.
+func jsonLogicValue(v any) (any, error) {
+	switch x := v.(type) {
+	case nil, string, bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+		return v, nil
+	case paramRef:
+		if x.param.InputType == FunctionCall {
+			return nil, newErrorUntranslatable("JSON Logic", fmt.Sprintf("the function %s has no JSON Logic equivalent", x.param.Name))
+		}
+		return map[string]any{"var": x.param.Name}, nil
+	case valueExpr:
+		return nil, newErrorUntranslatable("JSON Logic", "now() and arithmetic have no JSON Logic equivalent")
+	}
+
+	list := reflect.ValueOf(v)
+	if list.Kind() != reflect.Slice {
+		return nil, newErrorUntranslatable("JSON Logic", fmt.Sprintf("%s values have no JSON Logic equivalent", typeName(v)))
+	}
+	values := make([]any, list.Len())
+	for i := range values {
+		value, err := jsonLogicValue(list.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,226 @@
+package rule
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFromJSONLogic(t *testing.T) {
+	tests := []struct {
+		logic string
+		doc   map[string]any
+		want  bool
+	}{
+		{`{">=": [{"var": "age"}, 18]}`, map[string]any{"age": 20}, true},
+		{`{">=": [{"var": "age"}, 18]}`, map[string]any{"age": 17}, false},
+		{`{"<": [18, {"var": "age"}]}`, map[string]any{"age": 20}, true},
+		{`{"<=": [18, {"var": "age"}, 65]}`, map[string]any{"age": 65}, true},
+		{`{"<": [18, {"var": "age"}, 65]}`, map[string]any{"age": 65}, false},
+		{`{"==": [{"var": "address.city"}, "London"]}`, map[string]any{"address": map[string]any{"city": "London"}}, true},
+		{`{"===": [{"var": "active"}, true]}`, map[string]any{"active": true}, true},
+		{`{"!=": [{"var": "status"}, "banned"]}`, map[string]any{"status": "active"}, true},
+		{`{"==": [{"var": "price"}, 9.5]}`, map[string]any{"price": 9.5}, true},
+		{`{"and": [{">": [{"var": "a"}, 1]}, {">": [{"var": "b"}, 1]}, {">": [{"var": "c"}, 1]}]}`, map[string]any{"a": 2, "b": 2, "c": 1}, false},
+		{`{"or": [{"==": [{"var": "a"}, 1]}, {"==": [{"var": "b"}, 1]}]}`, map[string]any{"a": 0, "b": 1}, true},
+		{`{"!": [{"==": [{"var": "a"}, 1]}]}`, map[string]any{"a": 2}, true},
+		{`{"!": {"==": [{"var": "a"}, 1]}}`, map[string]any{"a": 1}, false},
+
+		// null
+		{`{"!=": [{"var": "email"}, null]}`, map[string]any{"email": "a@x.org"}, true},
+		{`{"!=": [{"var": "email"}, null]}`, map[string]any{}, false},
+		{`{"==": [{"var": "email"}, null]}`, map[string]any{}, true},
+		{`{"==": [null, {"var": "email"}]}`, map[string]any{"email": "a@x.org"}, false},
+
+		// in
+		{`{"in": [{"var": "lang"}, ["en", "fr"]]}`, map[string]any{"lang": "fr"}, true},
+		{`{"in": [{"var": "id"}, [1, 2, 3]]}`, map[string]any{"id": 4}, false},
+		{`{"in": [{"var": "score"}, [1, 2.5]]}`, map[string]any{"score": 2.5}, true},
+		{`{"in": [{"var": "code"}, "ABCDEF"]}`, map[string]any{"code": "CDE"}, true},
+		{`{"in": ["@example.com", {"var": "email"}]}`, map[string]any{"email": "ada@example.com"}, true},
+		{`{"in": [{"var": "role"}, {"var": "allowed"}]}`, map[string]any{"role": "dev", "allowed": []string{"admin", "dev"}}, true},
+
+		// Attributes on both sides
+		{`{">": [{"var": "shipped_at"}, {"var": ["ordered_at"]}]}`, map[string]any{"shipped_at": 5, "ordered_at": 3}, true},
+	}
+	for _, tt := range tests {
+		r, err := FromJSONLogic([]byte(tt.logic))
+		if err != nil {
+			t.Errorf("FromJSONLogic(%s) error: %v", tt.logic, err)
+			continue
+		}
+		got, err := r.EvaluateMap(tt.doc)
+		if err != nil {
+			t.Errorf("FromJSONLogic(%s).EvaluateMap(%v) error: %v", tt.logic, tt.doc, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("FromJSONLogic(%s).EvaluateMap(%v) = %v; want %v", tt.logic, tt.doc, got, tt.want)
+		}
+	}
+}
+
+func TestFromJSONLogicParams(t *testing.T) {
+	r, err := FromJSONLogic([]byte(`{"and": [{">": [{"var": "shipped_at"}, {"var": "ordered_at"}]}, {"in": [{"var": "id"}, [1, 2]]}]}`))
+	if err != nil {
+		t.Fatalf("FromJSONLogic error: %v", err)
+	}
+
+	// The attribute on the right-hand side follows the one on the left, as with ParseQuery
+	var names []string
+	for i, p := range r.Params {
+		if p.id != i {
+			t.Errorf("Params[%d].id = %d", i, p.id)
+		}
+		names = append(names, p.Name)
+	}
+	if got := strings.Join(names, ","); got != "shipped_at,ordered_at,id" {
+		t.Errorf("Params = %s; want shipped_at,ordered_at,id", got)
+	}
+	if got := r.Params[2].Expression; got != ArgTypeList {
+		t.Errorf("Params[2].Expression = %v; want %v", got, ArgTypeList)
+	}
+
+	ok, err := r.Evaluate([]Evaluation{
+		{Param: r.Params[0], Result: int64(5)},
+		{Param: r.Params[1], Result: int64(3)},
+		{Param: r.Params[2], Result: int64(2)},
+	})
+	if err != nil || !ok {
+		t.Errorf("Evaluate = %v, %v; want true", ok, err)
+	}
+}
+
+func TestFromJSONLogicInvalid(t *testing.T) {
+	tests := []struct {
+		logic  string
+		reason string
+	}{
+		{`{"==": [{"var": "a"}, 1]`, "unexpected EOF"},
+		{`{"==": [{"var": "a"}, 1]} {}`, "unexpected data"},
+		{`true`, "not an operation"},
+		{`{"==": [1, 2], "!=": [1, 2]}`, "not an operation"},
+		{`{"var": "active"}`, "truthiness"},
+		{`{"and": [{"var": "active"}]}`, "truthiness"},
+		{`{"and": []}`, "at least one operand"},
+		{`{"!": [{"==": [{"var": "a"}, 1]}, {"==": [{"var": "b"}, 1]}]}`, "one operand"},
+		{`{"+": [1, 2]}`, `the operation "+"`},
+		{`{"if": [{"var": "a"}, true, false]}`, `the operation "if"`},
+		{`{"==": [{"var": "a"}]}`, "two operands"},
+		{`{">": [1, {"var": "a"}, 3]}`, "two operands"},
+		{`{"==": [1, 1]}`, "needs a var"},
+		{`{"==": [{"var": "a"}, {"+": [1, 2]}]}`, "neither a var nor a constant"},
+		{`{"==": [{"var": ["a", 0]}, 1]}`, "default value"},
+		{`{"==": [{"var": "items.0"}, 1]}`, "not an attribute name"},
+		{`{"==": [{"var": 1}, 1]}`, "not an attribute name"},
+		{`{">": [{"var": "a"}, null]}`, "with null"},
+		{`{"==": [{"var": "a"}, [1, 2]]}`, "second operand"},
+		{`{"in": [{"var": "a"}, []]}`, "empty list"},
+		{`{"in": [{"var": "a"}, ["x", 1]]}`, "mixes strings"},
+		{`{"in": [{"var": "a"}, [1, true]]}`, "only strings or only numbers"},
+		{`{"in": [{"var": "a"}, 12]}`, "a list or a string"},
+		{`{"in": [1, {"var": "a"}]}`, "first operand"},
+		{`{"in": ["x", "xyz"]}`, "needs a var"},
+		{`{"==": [{"var": "a"}, {"x": 1, "y": 2}]}`, "neither a var nor a constant"},
+	}
+	for _, tt := range tests {
+		_, err := FromJSONLogic([]byte(tt.logic))
+		if !errors.Is(err, ErrorInvalidJSONLogic) || !strings.Contains(err.Error(), tt.reason) {
+			t.Errorf("FromJSONLogic(%s) error = %v; want ErrorInvalidJSONLogic mentioning %q", tt.logic, err, tt.reason)
+		}
+	}
+}
+
+func TestToJSONLogic(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`age gt 30`, `{">":[{"var":"age"},30]}`},
+		{`age ge 18 and age le 65 and active eq true`, `{"and":[{">=":[{"var":"age"},18]},{"<=":[{"var":"age"},65]},{"==":[{"var":"active"},true]}]}`},
+		{`a eq 1 or b eq 2 and c eq 3`, `{"or":[{"==":[{"var":"a"},1]},{"and":[{"==":[{"var":"b"},2]},{"==":[{"var":"c"},3]}]}]}`},
+		{`not (a eq 1 or b ne "x")`, `{"!":[{"or":[{"==":[{"var":"a"},1]},{"!=":[{"var":"b"},"x"]}]}]}`},
+		{`price lt 9.99`, `{"<":[{"var":"price"},9.99]}`},
+		{`address.city eq "London"`, `{"==":[{"var":"address.city"},"London"]}`},
+		{`deleted_at eq null`, `{"==":[{"var":"deleted_at"},null]}`},
+		{`email pr`, `{"!=":[{"var":"email"},null]}`},
+		{`not email pr`, `{"==":[{"var":"email"},null]}`},
+		{`lang in ["en", "fr"]`, `{"in":[{"var":"lang"},["en","fr"]]}`},
+		{`id nin [1, 2]`, `{"!":[{"in":[{"var":"id"},[1,2]]}]}`},
+		{`code in "ABCDEF"`, `{"in":[{"var":"code"},"ABCDEF"]}`},
+		{`email co "@example.com"`, `{"in":["@example.com",{"var":"email"}]}`},
+		{`shipped_at gt ordered_at`, `{">":[{"var":"shipped_at"},{"var":"ordered_at"}]}`},
+		{`count eq [i32]5`, `{"==":[{"var":"count"},5]}`},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.ToJSONLogic()
+		if err != nil {
+			t.Errorf("ToJSONLogic(%q) error: %v", tt.query, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("ToJSONLogic(%q) = %s; want %s", tt.query, got, tt.want)
+		}
+
+		// The JSON Logic reads back into the same Rule
+		back, err := FromJSONLogic(got)
+		if err != nil {
+			t.Errorf("FromJSONLogic(%s) error: %v", got, err)
+			continue
+		}
+		again, err := back.ToJSONLogic()
+		if err != nil || string(again) != string(got) {
+			t.Errorf("round trip of %q = %s, %v; want %s", tt.query, again, err, got)
+		}
+	}
+}
+
+func TestToJSONLogicUntranslatable(t *testing.T) {
+	functions := NewFunctionRegistry()
+	if err := functions.Register("get_score", func(args ...any) (any, error) { return 0, nil }, ArgTypeString); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	config := &Config{Functions: functions, CaseInsensitive: []string{"email"}}
+
+	tests := []struct {
+		query  string
+		reason string
+	}{
+		{`get_score("math") gt 90`, "function get_score"},
+		{`x eq get_score("math")`, "function get_score"},
+		{`price * quantity gt 1000`, "computed value"},
+		{`last_login gt now() - 30d`, "now()"},
+		{`emails[type eq "work"]`, "value path"},
+		{`any(tags) eq "vip"`, "quantifier any"},
+		{`len(tags) gt 3`, "computed value"},
+		{`email eq "a@x.org"`, "case-insensitive"},
+		{`name sw "A"`, "operator sw"},
+		{`name ew "z"`, "operator ew"},
+		{`sku mr "^x"`, "operator mr"},
+		{`client_ip wi "10.0.0.0/8"`, "operator wi"},
+		{`price eq [d]"9.99"`, "decimal.Decimal"},
+		{`created_at gt [date]"2025-01-01"`, "time.Time"},
+		{`timeout gt [dur]"1m"`, "time.Duration"},
+		{`version gt [semver]"1.2.3"`, "Semver"},
+		{`price in [d][1.5, 2.5]`, "decimal.Decimal"},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, config)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		_, err = r.ToJSONLogic()
+		if !errors.Is(err, ErrorUntranslatable) || !strings.Contains(err.Error(), tt.reason) {
+			t.Errorf("ToJSONLogic(%q) error = %v; want ErrorUntranslatable mentioning %q", tt.query, err, tt.reason)
+		}
+	}
+
+	var empty Rule
+	if _, err := empty.ToJSONLogic(); !errors.Is(err, ErrorNoExpression) {
+		t.Errorf("ToJSONLogic of an empty Rule: error = %v; want ErrorNoExpression", err)
+	}
+}
@@ -1,6 +1,10 @@
 package rule
 
-import "github.com/antlr4-go/antlr/v4"
+import (
+	"context"
+	"github.com/antlr4-go/antlr/v4"
+	"time"
+)
 
 // InputType indicates if a Parameter is for a function call (FunctionCall) or a direct attribute expression (Expression).
 type InputType int
@@ -46,7 +50,8 @@ const (
 	// ArgTypeNull indicates a nil or null value.
 	ArgTypeNull
 
-	// ArgTypeList indicates some bracketed list syntax, e.g. [1,2,3].
+	// ArgTypeList indicates some bracketed list syntax, e.g. [1,2,3]. The value is a typed slice
+	// ([]int64, []float64 or []string, or the annotated type as in [d][1.5, 2.5]).
 	ArgTypeList
 
 	// ArgTypeFloat32 indicates a float32 type.
@@ -66,6 +71,21 @@ const (
 
 	// ArgTypeDecimal indicates a decimal.Decimal type (from shopspring/decimal).
 	ArgTypeDecimal
+
+	// ArgTypeTime indicates a time.Time type, e.g. from [t]"2025-01-01T00:00:00Z" or [date]"2025-01-01".
+	ArgTypeTime
+
+	// ArgTypeDuration indicates a time.Duration type, e.g. from 30d or [dur]"72h".
+	ArgTypeDuration
+
+	// ArgTypeIP indicates a netip.Addr type, e.g. from [ip]"10.0.0.1".
+	ArgTypeIP
+
+	// ArgTypeCIDR indicates a netip.Prefix type, e.g. from [cidr]"10.0.0.0/8".
+	ArgTypeCIDR
+
+	// ArgTypeSemver indicates a Semver type, e.g. from [semver]"2.10.0".
+	ArgTypeSemver
 )
 
 // argToString maps ArgumentType to a short descriptor for debugging or logging.
@@ -84,6 +104,11 @@ var argToString = map[ArgumentType]string{
 	ArgTypeUnsignedInteger64: "uint64",
 	ArgTypeUnsignedInteger32: "uint32",
 	ArgTypeDecimal:           "decimal",
+	ArgTypeTime:              "time",
+	ArgTypeDuration:          "duration",
+	ArgTypeIP:                "ip",
+	ArgTypeCIDR:              "cidr",
+	ArgTypeSemver:            "semver",
 }
 
 // String returns the string representation of the ArgumentType (for debugging).
@@ -110,17 +135,39 @@ type FunctionArgument struct {
 //   - FunctionArguments: slice of arguments if it's a function call
 //   - strictTypeCheck: indicates if type annotations must be strictly enforced
 //   - Expression: if InputType == Expression, describes the type annotation discovered
+//   - caseInsensitive: indicates that string comparisons ignore case (see Config.CaseInsensitive)
 //   - operator: the SCIM-like operator (eq, gt, etc.)
-//   - compareValue: the RHS value for the comparison (number, string, decimal, etc.)
+//   - compareValue: the RHS value for the comparison (number, string, decimal, etc.);
+//     a *regexp.Regexp compiled by ParseQuery for the mr operator, or a valueExpr computed during
+//     evaluation when it involves now() or other attributes
+//   - leftExpr: a computed left-hand side such as "price * quantity", if any; Name is then the
+//     expression as written, and the Parameter is not listed in Rule.Params
+//   - quantifier: "any", "all" or "none" if the left-hand side is a quantifier such as any(tags),
+//     which applies the comparison to each element of the multi-valued attribute Name
+//   - Filter: for a value path such as emails[type eq "work"], the bracketed filter that at least
+//     one element of the multi-valued attribute Name must match; nil otherwise
 type Parameter struct {
 	id                int
 	Name              string
 	InputType         InputType
 	FunctionArguments []FunctionArgument
 	strictTypeCheck   bool
+	caseInsensitive   bool
 	Expression        ArgumentType
 	operator          string
 	compareValue      any
+	leftExpr          valueExpr
+	quantifier        string
+	Filter            *ValueFilter
+}
+
+// ValueFilter describes the bracketed filter of a SCIM value path, e.g. the type eq "work" in
+// emails[type eq "work"]. Params are the conditions of the filter, in the same form as Rule.Params;
+// their names are relative to each element of the multi-valued attribute (e.g. "type", not
+// "emails.type"). The filter is evaluated as part of the enclosing Rule.
+type ValueFilter struct {
+	Params   []Parameter
+	exprTree *exprTree
 }
 
 // exprTree is an internal node in the expression tree built during parsing.
@@ -149,18 +196,62 @@ type errorListener struct {
 //   - Params: all discovered parameters
 //   - exprTree: the root of the expression tree for logical ops
 //   - debugMode: flag enabling debug prints during evaluation
+//   - functions: registry used to compute FunctionCall parameters during evaluation
+//   - clock: source of the current time for now(), from Config.Now
 type Rule struct {
 	Params    []Parameter
 	exprTree  exprTree
 	debugMode bool
+	functions *FunctionRegistry
+	clock     func() time.Time
 }
 
 // Config controls optional ParseQuery() behaviors.
 //
 // Fields:
 //   - DebugMode: if true, evaluation debug lines are printed to stdout
+//   - Functions: if set, function calls are validated against it and invoked during evaluation
+//   - MaxPatternLength: if positive, longer regular expression patterns (mr) are rejected by ParseQuery
+//   - CaseInsensitive: attribute or function names whose eq, ne, co, sw, ew, in and nin comparisons
+//     ignore case, using Unicode case folding (like SCIM attributes with caseExact=false)
+//   - LeftToRightLogic: if true, "and" and "or" share one precedence and are grouped from left to right,
+//     as in releases before "and" bound tighter than "or"; parentheses still group as written
+//   - Now: clock used by the built-in now() function, e.g. a fixed time in tests; time.Now if nil
 type Config struct {
-	DebugMode bool
+	DebugMode        bool
+	Functions        *FunctionRegistry
+	MaxPatternLength int
+	CaseInsensitive  []string
+	LeftToRightLogic bool
+	Now              func() time.Time
+}
+
+// evalContext carries the state of a single evaluation through exprTree.evaluate.
+//
+// Fields:
+//   - ctx: context of the evaluation, handed to the Resolver and registered functions
+//   - values: param.id -> value supplied by the caller
+//   - functions: registry used for FunctionCall parameters that have no supplied value
+//   - resolver: lazily supplies any remaining parameter values
+//   - resolved: memoized function and Resolver results, keyed by Parameter.resolveKey
+//   - debugMode: flag enabling debug prints during evaluation
+//   - clock: source of the current time for now(), read at most once per evaluation into nowTime
+type evalContext struct {
+	ctx       context.Context
+	values    map[int]any
+	functions *FunctionRegistry
+	resolver  Resolver
+	resolved  map[string]resolvedValue
+	debugMode bool
+	clock     func() time.Time
+	nowTime   time.Time
+}
+
+// resolvedValue is a memoized function or Resolver result. found is false if the parameter
+// has no value, e.g. because the Resolver reported ErrorParameterNotFound.
+type resolvedValue struct {
+	value any
+	found bool
 }
 
 // Evaluation couples a parsed Parameter with an actual value for runtime evaluation.
@@ -0,0 +1,275 @@
+package rule
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"regexp"
+	"time"
+)
+
+// MongoOptions controls Rule.ToMongo.
+//
+// Fields:
+//   - Field: maps an attribute name (Parameter.Name, e.g. "address.city", or a name relative to the
+//     elements inside a value path) to the field path of the filter; return an error to reject an
+//     attribute. If nil, the name is used as is, so dotted names refer to embedded documents
+//   - Value: converts each value of the filter, e.g. a decimal.Decimal to the driver's Decimal128;
+//     if nil, values are used as is
+type MongoOptions struct {
+	Field func(name string) (string, error)
+	Value func(v any) (any, error)
+}
+
+// ToMongo translates the Rule into a MongoDB filter document, shaped like bson.M, e.g.
+//
+//	filter, err := ruleSet.ToMongo(nil)
+//	cursor, err := collection.Find(ctx, bson.M(filter))
+//
+// "and" and "or" become $and and $or (flattening chains of the same operator), and a negation
+// becomes $nor, which like "not" in Evaluate also matches documents where the field is missing.
+// eq, gt, lt, ge and le become $eq, $gt, $lt, $gte and $lte; ne and nin become $nin with null added,
+// so that a missing or null field does not match, as in Evaluate. "pr" becomes $exists with $ne: null.
+// "eq null" becomes $eq: null, which matches a missing or null field just like Evaluate does, and
+// "ne null" becomes $ne: null, the same as "pr". co, sw, ew and mr become $regex, with "i" in $options
+// for attributes listed in Config.CaseInsensitive (which eq, ne, in and nin honor the same way). A
+// value path such as emails[type eq "work"] and the quantifier any() become $elemMatch.
+//
+// Times and decimals are kept as time.Time and decimal.Decimal (see MongoOptions.Value), IP addresses
+// and prefixes become strings, and now() and arithmetic on constants are computed (with Config.Now).
+// all(), none(), len, wi, in / nin with a string, attributes or arithmetic on the right-hand side,
+// computed left-hand sides, [semver] and [dur] values and function calls fail with ErrorUntranslatable.
+func (g *Rule) ToMongo(opts *MongoOptions) (map[string]any, error) {
+	b := &mongoBuilder{
+		field: func(name string) (string, error) { return name, nil },
+		value: func(v any) (any, error) { return v, nil },
+		ec:    &evalContext{ctx: context.Background(), clock: g.clock},
+	}
+	if opts != nil {
+		if opts.Field != nil {
+			b.field = opts.Field
+		}
+		if opts.Value != nil {
+			b.value = opts.Value
+		}
+	}
+	if b.ec.clock == nil {
+		b.ec.clock = time.Now
+	}
+	return b.filter(&g.exprTree)
+}
+
+// mongoOperators maps the ordering operators to MongoDB query operators.
+var mongoOperators = map[string]string{
+	"eq": "$eq",
+	"gt": "$gt",
+	"lt": "$lt",
+	"ge": "$gte",
+	"le": "$lte",
+}
+
+// mongoBuilder holds the settings of Rule.ToMongo while it translates the exprTree.
+//
+// Fields:
+//   - field, value: from MongoOptions, or the identity
+//   - ec: computes now() and arithmetic on constants, reading the clock at most once
+type mongoBuilder struct {
+	field func(name string) (string, error)
+	value func(v any) (any, error)
+	ec    *evalContext
+}
+
+// filter translates an exprTree node into a filter document.
+func (b *mongoBuilder) filter(e *exprTree) (map[string]any, error) {
+	var doc map[string]any
+	if e.op == "" {
+		if e.param == nil {
+			return nil, ErrorNoExpression
+		}
+		var err error
+		if doc, err = b.comparison(e.param); err != nil {
+			return nil, err
+		}
+	} else {
+		var clauses []any
+		for _, child := range []*exprTree{e.left, e.right} {
+			sub, err := b.filter(child)
+			if err != nil {
+				return nil, err
+			}
+			// a and (b and c) is a single $and of a, b and c
+			if nested, ok := sub["$"+e.op].([]any); ok && len(sub) == 1 {
+				clauses = append(clauses, nested...)
+				continue
+			}
+			clauses = append(clauses, sub)
+		}
+		doc = map[string]any{"$" + e.op: clauses}
+	}
+
+	if e.not {
+		return map[string]any{"$nor": []any{doc}}, nil
+	}
+	return doc, nil
+}
+
+// comparison translates the leaf comparison of p into a filter document.
+func (b *mongoBuilder) comparison(p *Parameter) (map[string]any, error) {
+	if p.leftExpr != nil {
+		return nil, newErrorUntranslatable("MongoDB", fmt.Sprintf("the computed value %s has no MongoDB equivalent", p.Name))
+	}
+	if p.InputType == FunctionCall {
+		return nil, newErrorUntranslatable("MongoDB", fmt.Sprintf("the function %s has no MongoDB equivalent", p.Name))
+	}
+	field, err := b.field(p.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Filter != nil {
+		sub, err := b.filter(p.Filter.exprTree)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{field: map[string]any{"$elemMatch": sub}}, nil
+	}
+
+	ops, err := b.operators(p)
+	if err != nil {
+		return nil, err
+	}
+	switch p.quantifier {
+	case "":
+		return map[string]any{field: ops}, nil
+	case "any":
+		return map[string]any{field: map[string]any{"$elemMatch": ops}}, nil
+	}
+	return nil, newErrorUntranslatable("MongoDB", fmt.Sprintf("the quantifier %s(%s) has no MongoDB equivalent", p.quantifier, p.Name))
+}
+
+// operators translates the operator and value of p into the operator document of its field,
+// e.g. {"$gt": 30}.
+func (b *mongoBuilder) operators(p *Parameter) (map[string]any, error) {
+	fold := p.caseInsensitive && foldsCase(p.operator)
+
+	switch p.operator {
+	case "pr":
+		return map[string]any{"$exists": true, "$ne": nil}, nil
+	case "mr":
+		re, ok := p.compareValue.(*regexp.Regexp)
+		if !ok {
+			return nil, newErrorUntranslatable("MongoDB", "mr needs a pattern")
+		}
+		return map[string]any{"$regex": re.String()}, nil
+	case "co", "sw", "ew":
+		s, ok := p.compareValue.(string)
+		if !ok {
+			return nil, newErrorUntranslatable("MongoDB", fmt.Sprintf("%s needs a string value", p.operator))
+		}
+		pattern := regexp.QuoteMeta(s)
+		switch p.operator {
+		case "sw":
+			pattern = "^" + pattern
+		case "ew":
+			pattern += "$"
+		}
+		return mongoRegex(pattern, fold), nil
+	case "in", "nin":
+		return b.membership(p, fold)
+	case "wi":
+		return nil, newErrorUntranslatable("MongoDB", "wi has no MongoDB equivalent")
+	}
+
+	value, err := b.constant(p.compareValue)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := value.(string); ok && fold {
+		regex := mongoRegex("^"+regexp.QuoteMeta(s)+"$", true)
+		if p.operator == "ne" {
+			return map[string]any{"$ne": nil, "$not": regex}, nil
+		}
+		if p.operator == "eq" {
+			return regex, nil
+		}
+	}
+	if p.operator == "ne" {
+		if value == nil {
+			return map[string]any{"$ne": nil}, nil
+		}
+		return map[string]any{"$nin": []any{value, nil}}, nil
+	}
+	op, ok := mongoOperators[p.operator]
+	if !ok {
+		return nil, newErrorUntranslatable("MongoDB", fmt.Sprintf("the operator %s has no MongoDB equivalent", p.operator))
+	}
+	return map[string]any{op: value}, nil
+}
+
+// membership translates in and nin. Case-insensitive lists of strings become one regular expression.
+func (b *mongoBuilder) membership(p *Parameter, fold bool) (map[string]any, error) {
+	list := reflect.ValueOf(p.compareValue)
+	if list.Kind() != reflect.Slice {
+		return nil, newErrorUntranslatable("MongoDB", fmt.Sprintf("%s with a string checks for a substring", p.operator))
+	}
+
+	if strs, ok := p.compareValue.([]string); ok && fold {
+		pattern := "^(?:"
+		for i, s := range strs {
+			if i > 0 {
+				pattern += "|"
+			}
+			pattern += regexp.QuoteMeta(s)
+		}
+		regex := mongoRegex(pattern+")$", true)
+		if p.operator == "nin" {
+			return map[string]any{"$ne": nil, "$not": regex}, nil
+		}
+		return regex, nil
+	}
+
+	values := make([]any, list.Len(), list.Len()+1)
+	for i := range values {
+		value, err := b.constant(list.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	if p.operator == "nin" {
+		return map[string]any{"$nin": append(values, nil)}, nil
+	}
+	return map[string]any{"$in": values}, nil
+}
+
+// constant converts a comparison value for the filter. now() and arithmetic on constants are
+// computed; attributes and other computed values cannot be expressed in a filter document.
+func (b *mongoBuilder) constant(v any) (any, error) {
+	if hasRefs(v) {
+		return nil, newErrorUntranslatable("MongoDB", "comparisons with other attributes have no MongoDB equivalent")
+	}
+	if expr, ok := v.(valueExpr); ok {
+		var err error
+		if v, _, err = expr.eval(b.ec); err != nil {
+			return nil, err
+		}
+	}
+	switch x := v.(type) {
+	case netip.Addr:
+		v = x.String()
+	case netip.Prefix:
+		v = x.String()
+	case Semver, time.Duration:
+		return nil, newErrorUntranslatable("MongoDB", fmt.Sprintf("%s values have no MongoDB equivalent", typeName(v)))
+	}
+	return b.value(v)
+}
+
+// mongoRegex returns the $regex operator document for pattern, case-insensitive if fold is set.
+func mongoRegex(pattern string, fold bool) map[string]any {
+	if fold {
+		return map[string]any{"$regex": pattern, "$options": "i"}
+	}
+	return map[string]any{"$regex": pattern}
+}
@@ -0,0 +1,148 @@
+package rule
+
+import (
+	"errors"
+	"github.com/shopspring/decimal"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToMongo(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	config := &Config{Now: func() time.Time { return now }, CaseInsensitive: []string{"email", "lang"}}
+
+	type M = map[string]any
+	type A = []any
+	tests := []struct {
+		query string
+		want  M
+	}{
+		{`age gt 30`, M{"age": M{"$gt": int64(30)}}},
+		{`age ge 18 and age le 65`, M{"$and": A{M{"age": M{"$gte": int64(18)}}, M{"age": M{"$lte": int64(65)}}}}},
+		{`a eq 1 and b eq 2 and c lt 3`, M{"$and": A{M{"a": M{"$eq": int64(1)}}, M{"b": M{"$eq": int64(2)}}, M{"c": M{"$lt": int64(3)}}}}},
+		{`a eq 1 or b eq 2 and c eq 3`, M{"$or": A{
+			M{"a": M{"$eq": int64(1)}},
+			M{"$and": A{M{"b": M{"$eq": int64(2)}}, M{"c": M{"$eq": int64(3)}}}},
+		}}},
+		{`address.city eq "London"`, M{"address.city": M{"$eq": "London"}}},
+		{`status ne "active"`, M{"status": M{"$nin": A{"active", nil}}}},
+		{`not status eq "active"`, M{"$nor": A{M{"status": M{"$eq": "active"}}}}},
+		{`not (a eq 1 or b eq 2)`, M{"$nor": A{M{"$or": A{M{"a": M{"$eq": int64(1)}}, M{"b": M{"$eq": int64(2)}}}}}}},
+
+		// Presence and null
+		{`email pr`, M{"email": M{"$exists": true, "$ne": nil}}},
+		{`not email pr`, M{"$nor": A{M{"email": M{"$exists": true, "$ne": nil}}}}},
+		{`deleted_at eq null`, M{"deleted_at": M{"$eq": nil}}},
+		{`deleted_at ne null`, M{"deleted_at": M{"$ne": nil}}},
+		{`not deleted_at eq null`, M{"$nor": A{M{"deleted_at": M{"$eq": nil}}}}},
+
+		// Regular expressions
+		{`name co "a.b"`, M{"name": M{"$regex": `a\.b`}}},
+		{`name sw "Dr. "`, M{"name": M{"$regex": `^Dr\. `}}},
+		{`name ew "(x)"`, M{"name": M{"$regex": `\(x\)$`}}},
+		{`sku mr "^[A-Z]{3}-\\d+$"`, M{"sku": M{"$regex": `^[A-Z]{3}-\d+$`}}},
+		{`email ew "@Example.com"`, M{"email": M{"$regex": `@Example\.com$`, "$options": "i"}}},
+		{`email eq "Ada@Example.com"`, M{"email": M{"$regex": `^Ada@Example\.com$`, "$options": "i"}}},
+		{`email ne "a@x.org"`, M{"email": M{"$ne": nil, "$not": M{"$regex": `^a@x\.org$`, "$options": "i"}}}},
+
+		// Lists
+		{`role in ["admin", "dev"]`, M{"role": M{"$in": A{"admin", "dev"}}}},
+		{`id nin [1, 2]`, M{"id": M{"$nin": A{int64(1), int64(2), nil}}}},
+		{`lang in ["en", "fr"]`, M{"lang": M{"$regex": `^(?:en|fr)$`, "$options": "i"}}},
+
+		// Typed values
+		{`created_at gt [t]"2025-01-01T00:00:00Z"`, M{"created_at": M{"$gt": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}}},
+		{`last_login gt now() - 30d`, M{"last_login": M{"$gt": now.Add(-30 * 24 * time.Hour)}}},
+		{`client_ip eq [ip]"10.0.0.1"`, M{"client_ip": M{"$eq": "10.0.0.1"}}},
+		{`score ge 2 * 40`, M{"score": M{"$gte": int64(80)}}},
+
+		// Arrays
+		{`emails[type eq "work" and value ew "@example.com"]`, M{"emails": M{"$elemMatch": M{"$and": A{
+			M{"type": M{"$eq": "work"}},
+			M{"value": M{"$regex": `@example\.com$`}},
+		}}}}},
+		{`not emails[primary eq true]`, M{"$nor": A{M{"emails": M{"$elemMatch": M{"primary": M{"$eq": true}}}}}}},
+		{`any(tags) eq "vip"`, M{"tags": M{"$elemMatch": M{"$eq": "vip"}}}},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, config)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.ToMongo(nil)
+		if err != nil {
+			t.Errorf("ToMongo(%q) error: %v", tt.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ToMongo(%q) = %#v; want %#v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestToMongoOptions(t *testing.T) {
+	r, err := ParseQuery(`price ge [d]"9.99" and user.name eq "Ada"`, nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	got, err := r.ToMongo(&MongoOptions{
+		Field: func(name string) (string, error) { return "doc." + name, nil },
+		Value: func(v any) (any, error) {
+			if d, ok := v.(decimal.Decimal); ok {
+				return "decimal:" + d.String(), nil
+			}
+			return v, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("ToMongo error: %v", err)
+	}
+	want := map[string]any{"$and": []any{
+		map[string]any{"doc.price": map[string]any{"$gte": "decimal:9.99"}},
+		map[string]any{"doc.user.name": map[string]any{"$eq": "Ada"}},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToMongo = %#v; want %#v", got, want)
+	}
+
+	errRejected := errors.New("rejected")
+	_, err = r.ToMongo(&MongoOptions{Field: func(name string) (string, error) { return "", errRejected }})
+	if !errors.Is(err, errRejected) {
+		t.Errorf("ToMongo with a rejecting field mapper: error = %v; want %v", err, errRejected)
+	}
+}
+
+func TestToMongoUntranslatable(t *testing.T) {
+	tests := []struct {
+		query  string
+		reason string
+	}{
+		{`get_score("math") gt 90`, "function get_score"},
+		{`price * quantity gt 1000`, "computed value"},
+		{`shipped_at gt ordered_at`, "other attributes"},
+		{`all(scores) ge 50`, "quantifier all"},
+		{`none(roles) eq "banned"`, "quantifier none"},
+		{`len(items) gt 3`, "computed value"},
+		{`client_ip wi "10.0.0.0/8"`, "wi"},
+		{`name in "abc"`, "substring"},
+		{`version gt [semver]"1.2.3"`, "Semver"},
+		{`timeout gt [dur]"1m"`, "Duration"},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		_, err = r.ToMongo(nil)
+		if !errors.Is(err, ErrorUntranslatable) || !strings.Contains(err.Error(), tt.reason) {
+			t.Errorf("ToMongo(%q) error = %v; want ErrorUntranslatable mentioning %q", tt.query, err, tt.reason)
+		}
+	}
+
+	var empty Rule
+	if _, err := empty.ToMongo(nil); !errors.Is(err, ErrorNoExpression) {
+		t.Errorf("ToMongo of an empty Rule: error = %v; want ErrorNoExpression", err)
+	}
+}
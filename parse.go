@@ -1,54 +1,69 @@
 package rule
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/antlr4-go/antlr/v4"
 	"github.com/shopspring/decimal"
 	parser "github.com/sky93/go-rule/internal/antlr4"
+	"net/netip"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
 )
 
-// SyntaxError captures ANTLR syntax errors, storing them in errorListener.
+// SyntaxError captures ANTLR syntax errors, storing the first one in errorListener.
 func (l *errorListener) SyntaxError(_ antlr.Recognizer, _ any, line, column int, msg string, _ antlr.RecognitionException) {
+	if l.hasErrors {
+		return
+	}
 	l.hasErrors = true
 	l.errMsg = newSyntaxError(fmt.Sprintf("%d:%d: %s", line, column, msg))
 }
 
-// evaluate traverses the exprTree to evaluate the final boolean outcome, given a map
-// of param.id -> actual value. (This is used by Rule.Evaluate.)
-func (e *exprTree) evaluate(values map[int]any, debugMode bool) (bool, error) {
+// evaluate traverses the exprTree to evaluate the final boolean outcome, resolving parameter
+// values through ec. (This is used by Rule.Evaluate and friends.)
+func (e *exprTree) evaluate(ec *evalContext) (bool, error) {
 	if e == nil {
 		return false, ErrorNoExpression
 	}
 
-	// Handle logical operator nodes
+	// Handle logical operator nodes. Both short-circuit, so the right side is only
+	// resolved when the left side does not already decide the result.
 	switch e.op {
 	case "and":
-		lRes, lErr := e.left.evaluate(values, debugMode)
+		res, lErr := e.left.evaluate(ec)
 		if lErr != nil {
 			return false, lErr
 		}
-		rRes, rErr := e.right.evaluate(values, debugMode)
-		if rErr != nil {
-			return false, rErr
+		if res {
+			rRes, rErr := e.right.evaluate(ec)
+			if rErr != nil {
+				return false, rErr
+			}
+			res = rRes
 		}
-		res := lRes && rRes
 		if e.not {
 			return !res, nil
 		}
 		return res, nil
 
 	case "or":
-		lRes, lErr := e.left.evaluate(values, debugMode)
+		res, lErr := e.left.evaluate(ec)
 		if lErr != nil {
 			return false, lErr
 		}
-		rRes, rErr := e.right.evaluate(values, debugMode)
-		if rErr != nil {
-			return false, rErr
+		if !res {
+			rRes, rErr := e.right.evaluate(ec)
+			if rErr != nil {
+				return false, rErr
+			}
+			res = rRes
 		}
-		res := lRes || rRes
 		if e.not {
 			return !res, nil
 		}
@@ -57,24 +72,57 @@ func (e *exprTree) evaluate(values map[int]any, debugMode bool) (bool, error) {
 
 	// Leaf node => do param-based comparison
 	p := e.param
-	val, ok := values[p.id]
-	if !ok {
-		// If operator is "pr", presence is false => no value
-		if p.operator == "pr" {
-			return e.not == true, nil
+	var val any
+	var ok bool
+	var err error
+	if p.leftExpr != nil {
+		val, ok, err = p.leftExpr.eval(ec)
+	} else {
+		val, ok, err = ec.value(p)
+	}
+	if err != nil {
+		return false, err
+	}
+	if !ok || val == nil {
+		// A nil value (e.g. a JSON null) counts as missing. "eq null" matches it, while
+		// "pr", "ne null" and any other comparison treat the missing param as false.
+		matched := p.operator == "eq" && p.compareValue == nil && p.quantifier == ""
+		return matched != e.not, nil
+	}
+
+	if p.Filter != nil {
+		out, err := ec.matchAny(val, p.Filter)
+		if err != nil {
+			return false, err
 		}
-		// Otherwise treat missing param as false
-		if e.not {
-			return true, nil
+		return out != e.not, nil
+	}
+
+	compareValue := p.compareValue
+	if expr, ok := compareValue.(valueExpr); ok {
+		compareValue, ok, err = expr.eval(ec)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			// Like a missing attribute on the left
+			return e.not, nil
 		}
-		return false, nil
+	}
+	if p.caseInsensitive && foldsCase(p.operator) {
+		val, compareValue = foldCase(val), foldCase(compareValue)
 	}
 
-	out, err := compareOperator(val, p.operator, p.compareValue, p.strictTypeCheck)
-	if debugMode {
+	var out bool
+	if p.quantifier != "" {
+		out, err = compareQuantified(p.quantifier, val, p.operator, compareValue, p.strictTypeCheck, p.caseInsensitive && foldsCase(p.operator))
+	} else {
+		out, err = compareOperator(val, p.operator, compareValue, p.strictTypeCheck)
+	}
+	if ec.debugMode {
 		fmt.Printf(
 			"Name: %s, left Value: %v<%T>, Operator:%s, right Value: %v<%T>, Strict Type Check: %t, Result: %t\n",
-			p.Name, val, val, p.operator, p.compareValue, p.compareValue, p.strictTypeCheck, out,
+			p.Name, val, val, p.operator, compareValue, compareValue, p.strictTypeCheck, out,
 		)
 	}
 	if err != nil {
@@ -86,6 +134,126 @@ func (e *exprTree) evaluate(values map[int]any, debugMode bool) (bool, error) {
 	return out, nil
 }
 
+// value returns the runtime value of p: the value supplied by the caller if there is one,
+// then the result of the registered function for FunctionCall parameters, then the Resolver.
+// Function and Resolver results are memoized, so a repeated attribute or call is only resolved
+// once per evaluation. The second return value is false if the parameter has no value.
+//
+// Once the evaluation context is done, value fails with ErrorEvaluationAborted.
+func (ec *evalContext) value(p *Parameter) (any, bool, error) {
+	if err := ec.ctx.Err(); err != nil {
+		return nil, false, newErrorEvaluationAborted(err)
+	}
+	if val, ok := ec.values[p.id]; ok {
+		return val, true, nil
+	}
+
+	key := p.resolveKey()
+	if r, ok := ec.resolved[key]; ok {
+		return r.value, r.found, nil
+	}
+	r, err := ec.resolve(p)
+	if err != nil {
+		return nil, false, ec.abortedOr(err)
+	}
+	if ec.resolved == nil {
+		ec.resolved = make(map[string]resolvedValue)
+	}
+	ec.resolved[key] = r
+	return r.value, r.found, nil
+}
+
+// resolve computes the value of p with the registered function or the Resolver, without memoization.
+func (ec *evalContext) resolve(p *Parameter) (resolvedValue, error) {
+	if p.InputType == FunctionCall && ec.functions != nil {
+		val, ok, err := ec.functions.call(ec.ctx, p)
+		if err != nil {
+			return resolvedValue{}, err
+		}
+		if ok {
+			return resolvedValue{value: val, found: true}, nil
+		}
+	}
+	if ec.resolver == nil {
+		return resolvedValue{}, nil
+	}
+
+	val, err := ec.resolver.Resolve(ec.ctx, *p)
+	if err != nil && !errors.Is(err, ErrorParameterNotFound) {
+		return resolvedValue{}, err
+	}
+	return resolvedValue{value: val, found: err == nil}, nil
+}
+
+// matchAny reports whether at least one element of the multi-valued attribute val matches filter.
+// val may be a slice or an array; any other value counts as a single element. Each element is
+// evaluated on its own, with its sub-attributes resolved by elementResolver, while the context,
+// functions and clock of ec are shared.
+func (ec *evalContext) matchAny(val any, filter *ValueFilter) (bool, error) {
+	elems := []any{val}
+	if rv := reflect.ValueOf(val); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		elems = make([]any, rv.Len())
+		for i := range elems {
+			elems[i] = rv.Index(i).Interface()
+		}
+	}
+
+	for _, elem := range elems {
+		elemCtx := &evalContext{
+			ctx:       ec.ctx,
+			functions: ec.functions,
+			resolver:  elementResolver{elem: elem},
+			debugMode: ec.debugMode,
+			clock:     ec.now,
+		}
+		out, err := filter.exprTree.evaluate(elemCtx)
+		if err != nil {
+			return false, err
+		}
+		if out {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// abortedOr returns ErrorEvaluationAborted if the evaluation context is done
+// (the failure was most likely caused by it), and err otherwise.
+func (ec *evalContext) abortedOr(err error) error {
+	if ctxErr := ec.ctx.Err(); ctxErr != nil {
+		return newErrorEvaluationAborted(ctxErr)
+	}
+	return err
+}
+
+// now returns the current time for now(). The clock is read once, so every now() in a Rule
+// sees the same time during one evaluation.
+func (ec *evalContext) now() time.Time {
+	if ec.nowTime.IsZero() {
+		ec.nowTime = ec.clock()
+	}
+	return ec.nowTime
+}
+
+// resolveKey identifies the value a Parameter refers to, regardless of the operator it is used with:
+// the attribute name for expressions, or the function name plus its arguments for function calls.
+func (p *Parameter) resolveKey() string {
+	if p.InputType != FunctionCall {
+		return p.Name
+	}
+	var sb strings.Builder
+	sb.WriteString(p.Name)
+	sb.WriteByte('(')
+	for i, arg := range p.FunctionArguments {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		fmt.Fprintf(&sb, "%T:%v", arg.Value, arg.Value)
+	}
+	sb.WriteByte(')')
+	return sb.String()
+}
+
 // parseFunctionCall extracts the function name and arguments from the parse context.
 func (v *queryVisitor) parseFunctionCall(ctx parser.IFunctionCallContext) (string, []FunctionArgument, error) {
 	if ctx == nil {
@@ -101,6 +269,9 @@ func (v *queryVisitor) parseFunctionCall(ctx parser.IFunctionCallContext) (strin
 			if err != nil {
 				return "", nil, err
 			}
+			if _, ok := val.(valueExpr); ok {
+				return "", nil, newErrorInvalidFunctionCall(name, "arguments must be constant values")
+			}
 			args = append(args, FunctionArgument{
 				ArgumentType: t,
 				Value:        val,
@@ -125,7 +296,11 @@ func (v *queryVisitor) parseTypedValue(tv parser.ITypedValueContext) (any, Argum
 			userType = ann.GetText()
 			strictTypeCheck = true
 		}
-		value, argType, err := v.applyUserType(unquoteString(typedNode.STRING().GetText()), userType)
+		str, err := v.stringLiteral(typedNode.STRING())
+		if err != nil {
+			return nil, ArgTypeUnknown, false, err
+		}
+		value, argType, err := v.applyUserType(str, userType)
 		return value, argType, strictTypeCheck, err
 
 	case *parser.TypedDoubleContext:
@@ -136,18 +311,20 @@ func (v *queryVisitor) parseTypedValue(tv parser.ITypedValueContext) (any, Argum
 			strictTypeCheck = true
 		}
 
-		value, argType, err := v.applyUserType(typedNode.DOUBLE().GetText(), userType)
+		value, argType, err := v.applyUserType(signedText(typedNode.MINUS(), typedNode.DOUBLE()), userType)
 		return value, argType, strictTypeCheck, err
 
 	case *parser.TypedIntegerContext:
 		userType := "[i64]"
 		strictTypeCheck := false
+		rawVal := typedNode.GetText()
 		if ann := typedNode.TypeAnnotation(); ann != nil {
 			userType = ann.GetText()
 			strictTypeCheck = true
+			rawVal = strings.TrimPrefix(rawVal, userType)
 		}
 
-		value, argType, err := v.applyUserType(typedNode.GetText(), userType)
+		value, argType, err := v.applyUserType(rawVal, userType)
 		return value, argType, strictTypeCheck, err
 
 	default:
@@ -155,6 +332,14 @@ func (v *queryVisitor) parseTypedValue(tv parser.ITypedValueContext) (any, Argum
 	}
 }
 
+// signedText returns the text of the number token num, with a leading "-" if the minus token is present.
+func signedText(minus, num antlr.TerminalNode) string {
+	if minus != nil {
+		return "-" + num.GetText()
+	}
+	return num.GetText()
+}
+
 // applyUserType parses rawVal (string) according to the user-specified annotation in userType
 // (e.g. "f64", "i32", etc.) then returns the typed value plus its ArgumentType.
 func (v *queryVisitor) applyUserType(rawVal string, userType string) (any, ArgumentType, error) {
@@ -226,6 +411,52 @@ func (v *queryVisitor) applyUserType(rawVal string, userType string) (any, Argum
 		}
 		return float32(fl), ArgTypeFloat32, nil
 
+	case "t":
+		// An RFC 3339 timestamp, or Unix seconds for an integer literal such as [t]1735689600
+		if t, err := time.Parse(time.RFC3339Nano, rawVal); err == nil {
+			return t, ArgTypeTime, nil
+		}
+		sec, err := strconv.ParseInt(rawVal, 10, 64)
+		if err != nil {
+			return nil, ArgTypeUnknown, ErrorInvalidValue
+		}
+		return time.Unix(sec, 0).UTC(), ArgTypeTime, nil
+
+	case "date":
+		t, err := time.Parse(time.DateOnly, rawVal)
+		if err != nil {
+			return nil, ArgTypeUnknown, ErrorInvalidValue
+		}
+		return t, ArgTypeTime, nil
+
+	case "dur":
+		d, err := parseDuration(rawVal)
+		if err != nil {
+			return nil, ArgTypeUnknown, ErrorInvalidValue
+		}
+		return d, ArgTypeDuration, nil
+
+	case "ip":
+		addr, err := netip.ParseAddr(rawVal)
+		if err != nil {
+			return nil, ArgTypeUnknown, ErrorInvalidValue
+		}
+		return addr.Unmap(), ArgTypeIP, nil
+
+	case "cidr":
+		prefix, err := netip.ParsePrefix(rawVal)
+		if err != nil {
+			return nil, ArgTypeUnknown, ErrorInvalidValue
+		}
+		return prefix.Masked(), ArgTypeCIDR, nil
+
+	case "semver":
+		version, err := ParseSemver(rawVal)
+		if err != nil {
+			return nil, ArgTypeUnknown, err
+		}
+		return version, ArgTypeSemver, nil
+
 	default:
 		return nil, ArgTypeUnknown, ErrorUnknownType
 	}
@@ -235,6 +466,7 @@ func (v *queryVisitor) applyUserType(rawVal string, userType string) (any, Argum
 // typedVal, boolean, null, listOfInts, listOfDoubles, listOfStrings.
 //
 // If typedVal has a user annotation (e.g. [f64]"123.45"), it enforces strictTypeCheck for Evaluate.
+// Lists are parsed into typed slices, see parseList.
 func (v *queryVisitor) parseValue(valCtx parser.IValueContext) (any, ArgumentType, bool, error) {
 	switch node := valCtx.(type) {
 
@@ -252,26 +484,271 @@ func (v *queryVisitor) parseValue(valCtx parser.IValueContext) (any, ArgumentTyp
 		return nil, ArgTypeNull, false, nil
 
 	case *parser.ListOfIntsContext:
-		return valCtx.GetText(), ArgTypeList, false, nil
+		var raw []string
+		for sub := node.ListInts().SubListOfInts(); sub != nil; sub = sub.SubListOfInts() {
+			raw = append(raw, signedText(sub.MINUS(), sub.INT()))
+		}
+		return v.parseList(node.TypeAnnotation(), raw, "[i64]")
 
 	case *parser.ListOfDoublesContext:
-		return valCtx.GetText(), ArgTypeList, false, nil
+		var raw []string
+		for sub := node.ListDoubles().SubListOfDoubles(); sub != nil; sub = sub.SubListOfDoubles() {
+			raw = append(raw, signedText(sub.MINUS(), sub.DOUBLE()))
+		}
+		return v.parseList(node.TypeAnnotation(), raw, "[f64]")
 
 	case *parser.ListOfStringsContext:
-		return valCtx.GetText(), ArgTypeList, false, nil
+		var raw []string
+		for sub := node.ListStrings().SubListOfStrings(); sub != nil; sub = sub.SubListOfStrings() {
+			str, err := v.stringLiteral(sub.STRING())
+			if err != nil {
+				return nil, ArgTypeUnknown, false, err
+			}
+			raw = append(raw, str)
+		}
+		return v.parseList(node.TypeAnnotation(), raw, "[s]")
+
+	case *parser.DurationValContext:
+		d, err := parseDuration(node.DURATION().GetText())
+		if err != nil {
+			return nil, ArgTypeUnknown, false, err
+		}
+		return d, ArgTypeDuration, false, nil
+
+	case *parser.AttrValContext:
+		return v.parseAttrValue(node.AttrPath())
+
+	case *parser.LenValContext:
+		return v.parseLength(node)
+
+	case *parser.QuantifierValContext:
+		start := node.GetStart()
+		return nil, ArgTypeUnknown, false, newSyntaxError(fmt.Sprintf("%d:%d: %s() is only allowed as the left-hand side of a comparison", start.GetLine(), start.GetColumn(), node.GetOp().GetText()))
+
+	case *parser.ArithmeticValContext:
+		return v.parseArithmetic(node)
+
+	case *parser.ParenValContext:
+		return v.parseValue(node.Value())
 
 	default:
 		return "", ArgTypeUnknown, false, ErrorInvalidValue
 	}
 }
 
-// unquoteString removes the surrounding quotes of a string literal, plus minimal un-escaping.
-func unquoteString(s string) string {
-	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
-		inner := s[1 : len(s)-1]
-		inner = strings.ReplaceAll(inner, `\"`, `"`)
-		inner = strings.ReplaceAll(inner, `\\`, `\`)
-		return inner
+// parseAttrValue handles an attribute or function call on the right-hand side of a comparison, as in
+// "shipped_at gt ordered_at". It becomes a paramRef to a new Parameter, which visitCompareExp adds to
+// the parameters after the one on the left-hand side. The call now() is the built-in nowValue.
+func (v *queryVisitor) parseAttrValue(ctx parser.IAttrPathContext) (any, ArgumentType, bool, error) {
+	p := Parameter{InputType: Expression}
+	if call := ctx.FunctionCall(); call != nil {
+		name, args, err := v.parseFunctionCall(call)
+		if err != nil {
+			return nil, ArgTypeUnknown, false, err
+		}
+		if isNowCall(ctx) {
+			return nowValue{}, ArgTypeTime, false, nil
+		}
+		p.Name = name
+		p.InputType = FunctionCall
+		p.FunctionArguments = args
+	} else {
+		p.Name = v.getAttrName(ctx)
+	}
+
+	ref := paramRef{param: &p}
+	v.pendingRefs = append(v.pendingRefs, ref.param)
+	return ref, ArgTypeUnknown, false, nil
+}
+
+// parseLength handles len(attribute), the number of elements of a multi-valued attribute. Like with
+// parseAttrValue, the attribute becomes a Parameter of its own, while len itself is a lengthValue.
+func (v *queryVisitor) parseLength(node *parser.LenValContext) (any, ArgumentType, bool, error) {
+	ref, _, _, err := v.parseAttrValue(node.AttrPath())
+	if err != nil {
+		return nil, ArgTypeUnknown, false, err
+	}
+	attr, ok := ref.(paramRef)
+	if !ok {
+		start := node.AttrPath().GetStart()
+		return nil, ArgTypeUnknown, false, newSyntaxError(fmt.Sprintf("%d:%d: len() needs an attribute or a function call", start.GetLine(), start.GetColumn()))
+	}
+	return lengthValue{param: attr.param}, ArgTypeInteger64, false, nil
+}
+
+// parseArithmetic handles value op value, where op is +, -, *, / or %. Constant operands are computed
+// right away; an expression involving now() or an attribute becomes an arithmeticValue, computed during
+// evaluation. Its constant parts are still checked here, so e.g. now() + now() fails with
+// ErrorInvalidArithmetic and 1 / 0 with ErrorDivisionByZero.
+func (v *queryVisitor) parseArithmetic(node *parser.ArithmeticValContext) (any, ArgumentType, bool, error) {
+	left, _, _, err := v.parseValue(node.Value(0))
+	if err != nil {
+		return nil, ArgTypeUnknown, false, err
+	}
+	right, _, _, err := v.parseValue(node.Value(1))
+	if err != nil {
+		return nil, ArgTypeUnknown, false, err
+	}
+
+	// Attributes have no value here, so only expressions of constants and now() are checked
+	expr := arithmeticValue{op: node.GetOp().GetText(), left: left, right: right}
+	result, _, err := expr.eval(&evalContext{ctx: context.Background(), clock: func() time.Time { return time.Time{} }})
+	if err != nil {
+		return nil, ArgTypeUnknown, false, err
+	}
+	argType := ArgTypeUnknown
+	switch result.(type) {
+	case time.Time:
+		argType = ArgTypeTime
+	case time.Duration:
+		argType = ArgTypeDuration
+	case int64:
+		argType = ArgTypeInteger64
+	case uint64:
+		argType = ArgTypeUnsignedInteger64
+	case float64:
+		argType = ArgTypeFloat64
+	case decimal.Decimal:
+		argType = ArgTypeDecimal
+	}
+
+	_, leftExpr := left.(valueExpr)
+	_, rightExpr := right.(valueExpr)
+	if leftExpr || rightExpr {
+		return expr, argType, false, nil
+	}
+	return result, argType, false, nil
+}
+
+// parseList converts the elements of a list literal with applyUserType and collects them in a slice
+// of the resulting Go type: []int64 for [1, 2], []float64 for [1.5, 2.5] and []string for ["a", "b"]
+// by default, or e.g. []decimal.Decimal for [d][1.5, 2.5]. Like typed values, an annotated list
+// enforces strictTypeCheck for Evaluate.
+func (v *queryVisitor) parseList(ann parser.ITypeAnnotationContext, raw []string, userType string) (any, ArgumentType, bool, error) {
+	strictTypeCheck := false
+	if ann != nil {
+		userType = ann.GetText()
+		strictTypeCheck = true
+	}
+
+	var list reflect.Value
+	for i, rawVal := range raw {
+		value, _, err := v.applyUserType(rawVal, userType)
+		if err != nil {
+			return nil, ArgTypeUnknown, false, err
+		}
+		if i == 0 {
+			list = reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(value)), 0, len(raw))
+		}
+		list = reflect.Append(list, reflect.ValueOf(value))
+	}
+	return list.Interface(), ArgTypeList, strictTypeCheck, nil
+}
+
+// stringLiteral decodes the STRING token node with unquoteString. An invalid escape sequence is
+// reported as a syntax error at its own line and column.
+func (v *queryVisitor) stringLiteral(node antlr.TerminalNode) (string, error) {
+	tok := node.GetSymbol()
+	text := tok.GetText()
+	value, offset, err := unquoteString(text)
+	if err != nil {
+		line, column := tok.GetLine(), tok.GetColumn()
+		before := text[:offset]
+		if nl := strings.LastIndexByte(before, '\n'); nl >= 0 {
+			line += strings.Count(before, "\n")
+			column = utf8.RuneCountInString(before[nl+1:])
+		} else {
+			column += utf8.RuneCountInString(before)
+		}
+		return "", newSyntaxError(fmt.Sprintf("%d:%d: %v", line, column, err))
+	}
+	return value, nil
+}
+
+// unquoteString removes the surrounding double or single quotes of a string literal and decodes its
+// escape sequences like JSON does: \", \\, \/, \b, \f, \n, \r, \t and \uXXXX, including UTF-16
+// surrogate pairs (a lone surrogate becomes U+FFFD). \' is accepted as well, for single-quoted strings.
+// On an invalid escape, the byte offset of its backslash in s is returned with the error.
+func unquoteString(s string) (string, int, error) {
+	if len(s) < 2 || (s[0] != '"' && s[0] != '\'') || s[len(s)-1] != s[0] {
+		return s, 0, nil
+	}
+	inner := s[1 : len(s)-1]
+	if !strings.Contains(inner, `\`) {
+		return inner, 0, nil
+	}
+
+	var b strings.Builder
+	b.Grow(len(inner))
+	for i := 0; i < len(inner); i++ {
+		c := inner[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		offset := i + 1
+		if i+1 >= len(inner) {
+			return "", offset, errors.New("unterminated escape sequence")
+		}
+		i++
+		switch inner[i] {
+		case '"', '\'', '\\', '/':
+			b.WriteByte(inner[i])
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			r, ok := parseHex4(inner[i+1:])
+			if !ok {
+				return "", offset, errors.New("invalid unicode escape, want \\u followed by 4 hex digits")
+			}
+			i += 4
+			if utf16.IsSurrogate(r) {
+				pair := utf8.RuneError
+				if strings.HasPrefix(inner[i+1:], `\u`) {
+					if low, ok := parseHex4(inner[i+3:]); ok {
+						pair = utf16.DecodeRune(r, low)
+					}
+				}
+				if pair != utf8.RuneError {
+					i += 6
+				}
+				r = pair
+			}
+			b.WriteRune(r)
+		default:
+			r, _ := utf8.DecodeRuneInString(inner[i:])
+			return "", offset, fmt.Errorf("invalid escape sequence \\%c", r)
+		}
+	}
+	return b.String(), 0, nil
+}
+
+// parseHex4 parses the 4 hex digits at the start of s, as in a \uXXXX escape.
+func parseHex4(s string) (rune, bool) {
+	if len(s) < 4 {
+		return 0, false
+	}
+	var r rune
+	for _, c := range []byte(s[:4]) {
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c -= 'a' - 10
+		case 'A' <= c && c <= 'F':
+			c -= 'A' - 10
+		default:
+			return 0, false
+		}
+		r = r<<4 | rune(c)
 	}
-	return s
+	return r, true
 }
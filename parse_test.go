@@ -1,8 +1,15 @@
 package rule
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/shopspring/decimal"
+	"net/netip"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseTypedValueAnnotations(t *testing.T) {
@@ -12,6 +19,7 @@ func TestParseTypedValueAnnotations(t *testing.T) {
 		wantVal   any
 	}{
 		{`[i32]"123"`, ArgTypeInteger32, int32(123)},
+		{`[i32]-7`, ArgTypeInteger32, int32(-7)},
 		{`[i64]"456"`, ArgTypeInteger64, int64(456)},
 		{`[f64]"3.14"`, ArgTypeFloat64, 3.14},
 		{`[f32]"2.5"`, ArgTypeFloat32, float32(2.5)},
@@ -19,6 +27,10 @@ func TestParseTypedValueAnnotations(t *testing.T) {
 		{`[ui]"42"`, ArgTypeUnsignedInteger, uint(42)},
 		{`[d]"12.34"`, ArgTypeDecimal, decimal.NewFromFloat(12.34)},
 		{`[s]"Hello"`, ArgTypeString, "Hello"},
+		{`[t]"2025-01-01T00:00:00Z"`, ArgTypeTime, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{`[t]"2025-01-01T02:30:00.5+02:00"`, ArgTypeTime, time.Date(2025, 1, 1, 0, 30, 0, 5e8, time.UTC)},
+		{`[t]1735689600`, ArgTypeTime, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{`[date]"2025-01-01"`, ArgTypeTime, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
 	}
 
 	for _, tc := range table {
@@ -48,6 +60,11 @@ func TestParseTypedValueAnnotations(t *testing.T) {
 			if !ok || !decVal.Equal(v) {
 				t.Errorf("expected decimal %v, got %v", v, gotVal)
 			}
+		case time.Time:
+			timeVal, ok := gotVal.(time.Time)
+			if !ok || !timeVal.Equal(v) {
+				t.Errorf("expected time %v, got %v", v, gotVal)
+			}
 		default:
 			if gotVal != v {
 				t.Errorf("expected value %v, got %v", v, gotVal)
@@ -80,6 +97,209 @@ func TestParseFunctionCall(t *testing.T) {
 	}
 }
 
+func TestParseListValues(t *testing.T) {
+	tests := []struct {
+		query      string
+		want       any
+		wantStrict bool
+	}{
+		{`id in [10, 20]`, []int64{10, 20}, false},
+		{`id nin [10,20,30]`, []int64{10, 20, 30}, false},
+		{`price in [1.5, 2.25]`, []float64{1.5, 2.25}, false},
+		{`lang in ["en", "fr"]`, []string{"en", "fr"}, false},
+		{`lang in ["a\"b"]`, []string{`a"b`}, false},
+		{`id in [i32][1, 2]`, []int32{1, 2}, true},
+		{`price in [d][1.5, 2.5]`, []decimal.Decimal{decimal.RequireFromString("1.5"), decimal.RequireFromString("2.5")}, true},
+		{`code in [s]["1"]`, []string{"1"}, true},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Errorf("ParseQuery(%q) => unexpected error: %v", tt.query, err)
+			continue
+		}
+		p := r.Params[0]
+		if p.Expression != ArgTypeList {
+			t.Errorf("ParseQuery(%q) => ArgType=%v, want %v", tt.query, p.Expression, ArgTypeList)
+		}
+		if p.strictTypeCheck != tt.wantStrict {
+			t.Errorf("ParseQuery(%q) => strictTypeCheck=%v, want %v", tt.query, p.strictTypeCheck, tt.wantStrict)
+		}
+		if fmt.Sprintf("%T %v", p.compareValue, p.compareValue) != fmt.Sprintf("%T %v", tt.want, tt.want) {
+			t.Errorf("ParseQuery(%q) => %T %v, want %T %v", tt.query, p.compareValue, p.compareValue, tt.want, tt.want)
+		}
+	}
+
+	if _, err := ParseQuery(`id in [i32][1, 9999999999]`, nil); err == nil {
+		t.Error("expected an error for an out of range list element")
+	}
+}
+
+func TestParseMatchPattern(t *testing.T) {
+	for _, query := range []string{`sku mr "^[A-Z]{3}-\\d+$"`, `sku MATCH "^[A-Z]{3}-\\d+$"`} {
+		r, err := ParseQuery(query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) => unexpected error: %v", query, err)
+		}
+		p := r.Params[0]
+		if p.operator != "mr" {
+			t.Errorf("ParseQuery(%q) => operator %q, want mr", query, p.operator)
+		}
+		re, ok := p.compareValue.(*regexp.Regexp)
+		if !ok || re.String() != `^[A-Z]{3}-\d+$` {
+			t.Errorf("ParseQuery(%q) => compareValue %#v, want the compiled pattern", query, p.compareValue)
+		}
+	}
+
+	tests := []struct {
+		query   string
+		config  *Config
+		errText string
+	}{
+		{`sku mr "[a-"`, nil, "line 1:7: error parsing regexp"},
+		{`a eq 1 and sku mr "(x"`, nil, "line 1:18:"},
+		{`sku mr 15`, nil, "line 1:7: the pattern must be a string"},
+		{`sku mr "^abc$"`, &Config{MaxPatternLength: 4}, "line 1:7: the pattern is longer than 4 characters"},
+	}
+	for _, tt := range tests {
+		_, err := ParseQuery(tt.query, tt.config)
+		if !errors.Is(err, ErrorInvalidPattern) || !strings.Contains(err.Error(), tt.errText) {
+			t.Errorf("ParseQuery(%q) error = %v; want ErrorInvalidPattern containing %q", tt.query, err, tt.errText)
+		}
+	}
+
+	if _, err := ParseQuery(`sku mr "^abc$"`, &Config{MaxPatternLength: 5}); err != nil {
+		t.Errorf("pattern within MaxPatternLength => unexpected error: %v", err)
+	}
+}
+
+func TestUnquoteString(t *testing.T) {
+	tests := []struct {
+		literal string
+		want    string
+	}{
+		{`"plain"`, "plain"},
+		{`"say \"hi\""`, `say "hi"`},
+		{`"a\\b\/c"`, `a\b/c`},
+		{`"tab\tnew\nline\r\b\f"`, "tab\tnew\nline\r\b\f"},
+		{`"caf\u00e9"`, "café"},
+		{`"caf\u00E9"`, "café"},
+		{`"\ud83d\ude00"`, "\U0001F600"},
+		{`"\ud83d"`, "\uFFFD"},
+		{`"\ude00x"`, "\uFFFDx"},
+		{`"\ud83d\u0041"`, "\uFFFDA"},
+		{`'single'`, "single"},
+		{`'it\'s "quoted"'`, `it's "quoted"`},
+		{`'\u00e9\n'`, "é\n"},
+	}
+	for _, tt := range tests {
+		got, _, err := unquoteString(tt.literal)
+		if err != nil {
+			t.Errorf("unquoteString(%s) error: %v", tt.literal, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("unquoteString(%s) = %q; want %q", tt.literal, got, tt.want)
+		}
+		if tt.literal[0] == '"' {
+			var viaJSON string
+			if err := json.Unmarshal([]byte(tt.literal), &viaJSON); err != nil || viaJSON != got {
+				t.Errorf("unquoteString(%s) = %q; encoding/json gives %q (%v)", tt.literal, got, viaJSON, err)
+			}
+		}
+	}
+
+	invalid := []struct {
+		literal    string
+		wantOffset int
+	}{
+		{`"bad \q"`, 5},
+		{`"\u12"`, 1},
+		{`"ab\u12G4"`, 3},
+		{`'\x41'`, 1},
+	}
+	for _, tt := range invalid {
+		if _, offset, err := unquoteString(tt.literal); err == nil || offset != tt.wantOffset {
+			t.Errorf("unquoteString(%s) = offset %d, err %v; want an error at offset %d", tt.literal, offset, err, tt.wantOffset)
+		}
+	}
+}
+
+func TestParseStringEscapes(t *testing.T) {
+	r, err := ParseQuery(`name eq "a\tb" and city in ['Z\u00fcrich', "K\u00f6ln"]`, nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	got, err := r.EvaluateMap(map[string]any{"name": "a\tb", "city": "Zürich"})
+	if err != nil || !got {
+		t.Errorf("EvaluateMap = %v, %v; want true, nil", got, err)
+	}
+
+	tests := []struct {
+		query   string
+		wantPos string
+	}{
+		{`name eq "bad \q"`, "line 1:13:"},
+		{`name eq 'caf\u00g9'`, "line 1:12:"},
+		{"a eq 1 and\n  city in [\"ok\", \"x\\z\"]", "line 2:19:"},
+		{"name eq \"two\nlines \\e\"", "line 2:6:"},
+	}
+	for _, tt := range tests {
+		_, err := ParseQuery(tt.query, nil)
+		if !errors.Is(err, ErrorSyntaxError) || !strings.Contains(err.Error(), tt.wantPos) {
+			t.Errorf("ParseQuery(%q) error = %v; want a syntax error %q", tt.query, err, tt.wantPos)
+		}
+	}
+}
+
+func TestParseNetworks(t *testing.T) {
+	tests := []struct {
+		query    string
+		wantType ArgumentType
+		want     any
+	}{
+		{`ip eq [ip]"10.0.0.1"`, ArgTypeIP, netip.MustParseAddr("10.0.0.1")},
+		{`ip eq [ip]"::ffff:10.0.0.1"`, ArgTypeIP, netip.MustParseAddr("10.0.0.1")},
+		{`ip in [cidr]"10.1.2.3/8"`, ArgTypeCIDR, netip.MustParsePrefix("10.0.0.0/8")},
+		{`ip wi "2001:db8::/32"`, ArgTypeCIDR, netip.MustParsePrefix("2001:db8::/32")},
+		{`ip within [ip]"10.0.0.1"`, ArgTypeCIDR, netip.MustParsePrefix("10.0.0.1/32")},
+		{`ip WI ["10.0.0.0/8", "192.168.0.0/16"]`, ArgTypeList, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("192.168.0.0/16")}},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Errorf("ParseQuery(%q) error: %v", tt.query, err)
+			continue
+		}
+		p := r.Params[0]
+		if p.Expression != tt.wantType || fmt.Sprint(p.compareValue) != fmt.Sprint(tt.want) {
+			t.Errorf("ParseQuery(%q) = %v (%v); want %v (%v)", tt.query, p.compareValue, p.Expression, tt.want, tt.wantType)
+		}
+		if p.operator == "within" {
+			t.Errorf("ParseQuery(%q) kept operator %q; want it normalized to wi", tt.query, p.operator)
+		}
+	}
+
+	errorTests := []struct {
+		query   string
+		wantErr error
+		wantPos string
+	}{
+		{`ip eq [ip]"10.0.0.256"`, ErrorInvalidValue, ""},
+		{`ip in [cidr]"10.0.0.0/33"`, ErrorInvalidValue, ""},
+		{`ip wi "10.0.0.0"`, ErrorInvalidNetwork, "line 1:6:"},
+		{`ip wi ["10.0.0.0/8", "nope"]`, ErrorInvalidNetwork, "line 1:6:"},
+		{`ip wi 10`, ErrorInvalidNetwork, "line 1:6:"},
+	}
+	for _, tt := range errorTests {
+		_, err := ParseQuery(tt.query, nil)
+		if !errors.Is(err, tt.wantErr) || !strings.Contains(fmt.Sprint(err), tt.wantPos) {
+			t.Errorf("ParseQuery(%q) error = %v; want %v %s", tt.query, err, tt.wantErr, tt.wantPos)
+		}
+	}
+}
+
 func TestParseNestedParentheses(t *testing.T) {
 	query := `((age gt 18) and (score lt 100)) or (status eq "active")`
 	r, err := ParseQuery(query, nil)
@@ -92,6 +312,52 @@ func TestParseNestedParentheses(t *testing.T) {
 	}
 }
 
+func TestParseKeywordAttributeNames(t *testing.T) {
+	doc := map[string]any{
+		"match":  "x",
+		"within": 1,
+		"nin":    []string{"a"},
+		"pr":     true,
+		"in":     2,
+		"Eq":     3,
+		"spec":   map[string]any{"in": 4, "wi": "10.0.0.1"},
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`match eq "x" and match match "^x$"`, true},
+		{`within eq 1 and within in [1, 2]`, true},
+		{`any(nin) eq "a" and len(nin) eq 1`, true},
+		{`pr pr and pr eq true and not missing pr`, true},
+		{`in in [2] and in nin [3]`, true},
+		{`Eq Eq 3 and Eq gt in`, true},
+		{`spec.in eq 4 and spec.wi wi "10.0.0.0/8"`, true},
+		{`spec[in eq 4]`, true},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.EvaluateMap(doc)
+		if err != nil {
+			t.Fatalf("EvaluateMap(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateMap(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+	}
+
+	// The logical keywords, booleans and null stay reserved
+	for _, query := range []string{`and eq 1`, `or pr`, `not eq 1`, `true eq 1`, `null pr`} {
+		if _, err := ParseQuery(query, nil); !errors.Is(err, ErrorSyntaxError) {
+			t.Errorf("ParseQuery(%q) error = %v; want ErrorSyntaxError", query, err)
+		}
+	}
+}
+
 func TestParseInvalidQueries(t *testing.T) {
 	queries := []string{
 		``,                        // empty
@@ -0,0 +1,271 @@
+package rule
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Resolver supplies parameter values lazily during evaluation, e.g. from a database or cache.
+// Resolve is only called for parameters the evaluation actually needs: "and" / "or" short-circuit,
+// and each attribute (or function call with the same arguments) is resolved at most once per
+// evaluation. Return ErrorParameterNotFound if the parameter has no value; any other error aborts
+// the evaluation.
+type Resolver interface {
+	Resolve(ctx context.Context, param Parameter) (any, error)
+}
+
+// ResolverFunc adapts an ordinary function to the Resolver interface.
+type ResolverFunc func(ctx context.Context, param Parameter) (any, error)
+
+// Resolve calls f(ctx, param).
+func (f ResolverFunc) Resolve(ctx context.Context, param Parameter) (any, error) {
+	return f(ctx, param)
+}
+
+// documentResolver resolves Expression parameters by walking a map or struct (see lookupPath).
+type documentResolver struct {
+	root any
+}
+
+// Resolve looks up param.Name in the document.
+func (d documentResolver) Resolve(_ context.Context, param Parameter) (any, error) {
+	if param.InputType != Expression {
+		return nil, ErrorParameterNotFound
+	}
+	if val, ok := lookupPath(d.root, param.Name); ok {
+		return val, nil
+	}
+	return nil, ErrorParameterNotFound
+}
+
+// elementResolver resolves the sub-attributes of one element of a multi-valued attribute, for
+// the filter of a value path (see evalContext.matchAny). Like in SCIM, an element that is neither
+// a map nor a struct, such as a string in a list of tags, is the value of its "value" sub-attribute.
+type elementResolver struct {
+	elem any
+}
+
+// Resolve looks up param.Name in the element.
+func (r elementResolver) Resolve(ctx context.Context, param Parameter) (any, error) {
+	if param.InputType == Expression && param.Name == "value" {
+		if v, ok := indirect(reflect.ValueOf(r.elem)); ok && v.Kind() != reflect.Map && v.Kind() != reflect.Struct {
+			return v.Interface(), nil
+		}
+	}
+	return documentResolver{root: r.elem}.Resolve(ctx, param)
+}
+
+// structPlan maps every attribute name a struct type exposes to the field index path
+// (as used by reflect.Value.FieldByIndex) that reaches it, embedded structs included.
+type structPlan map[string][]int
+
+// structPlans caches one structPlan per reflect.Type, so struct tags are only inspected once.
+var structPlans sync.Map
+
+// lookupPath resolves an attribute path (e.g. "user.address.city") against root, which may be
+// a map with string keys, a struct, or a pointer to either. A key matching the full path wins;
+// otherwise each dot-separated segment walks one level deeper. The second return value is false
+// when any segment is missing, a nil pointer is hit along the way, or the value itself is nil
+// (e.g. a JSON null), so that a null attribute behaves exactly like a missing one.
+func lookupPath(root any, path string) (any, bool) {
+	if doc, ok := root.(map[string]any); ok {
+		if val, found := doc[path]; found {
+			return leafValue(reflect.ValueOf(val))
+		}
+	}
+
+	cur := reflect.ValueOf(root)
+	if val, ok := lookupKey(cur, path); ok {
+		return leafValue(val)
+	}
+	for _, key := range strings.Split(path, ".") {
+		next, ok := lookupKey(cur, key)
+		if !ok {
+			return nil, false
+		}
+		cur = next
+	}
+	return leafValue(cur)
+}
+
+// lookupKey returns the map entry or struct field named key, following pointers and interfaces.
+func lookupKey(v reflect.Value, key string) (reflect.Value, bool) {
+	v, ok := indirect(v)
+	if !ok {
+		return reflect.Value{}, false
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		val := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		return val, val.IsValid()
+
+	case reflect.Struct:
+		index, found := planFor(v.Type())[key]
+		if !found {
+			return reflect.Value{}, false
+		}
+		return fieldByIndex(v, index)
+
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+// indirect dereferences pointers and interfaces until it reaches a concrete value.
+// It reports false if a nil pointer or nil interface is found.
+func indirect(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, v.IsValid()
+}
+
+// leafValue unwraps the resolved value for comparison.
+// It reports false for nil values, nil pointers and nil interfaces.
+func leafValue(v reflect.Value) (any, bool) {
+	v, ok := indirect(v)
+	if !ok {
+		return nil, false
+	}
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return nil, false
+		}
+	}
+	return v.Interface(), true
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, except that it reports false instead of
+// panicking when an embedded struct pointer on the way is nil.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// planFor returns the cached structPlan for t, building it on first use.
+func planFor(t reflect.Type) structPlan {
+	if plan, ok := structPlans.Load(t); ok {
+		return plan.(structPlan)
+	}
+	plan, _ := structPlans.LoadOrStore(t, buildStructPlan(t))
+	return plan.(structPlan)
+}
+
+// buildStructPlan collects the exported fields of t, level by level, so that fields of embedded
+// structs are promoted the same way encoding/json does: a shallower field hides a deeper one with
+// the same name, and at the same depth a tagged field wins over untagged ones. Any other conflict
+// makes the name ambiguous, and it is dropped.
+func buildStructPlan(t reflect.Type) structPlan {
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+	type candidate struct {
+		index  []int
+		tagged bool
+	}
+
+	plan := make(structPlan)
+	decided := make(map[string]bool)
+	visited := make(map[reflect.Type]bool)
+	level := []embedded{{typ: t}}
+	for len(level) > 0 {
+		var next []embedded
+		var names []string
+		candidates := make(map[string][]candidate)
+		for _, e := range level {
+			// A type embedded twice at the same depth is walked twice, so its fields conflict.
+			if visited[e.typ] {
+				continue
+			}
+
+			for i := 0; i < e.typ.NumField(); i++ {
+				f := e.typ.Field(i)
+				name, tagged, skip := fieldName(f)
+				if skip {
+					continue
+				}
+				index := append(append([]int(nil), e.index...), i)
+
+				ft := f.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if f.Anonymous && !tagged && ft.Kind() == reflect.Struct {
+					next = append(next, embedded{typ: ft, index: index})
+					continue
+				}
+				if !f.IsExported() || decided[name] {
+					continue
+				}
+				if _, seen := candidates[name]; !seen {
+					names = append(names, name)
+				}
+				candidates[name] = append(candidates[name], candidate{index: index, tagged: tagged})
+			}
+		}
+		for _, e := range level {
+			visited[e.typ] = true
+		}
+
+		for _, name := range names {
+			decided[name] = true
+			var winner []int
+			ambiguous := false
+			for _, c := range candidates[name] {
+				if !c.tagged {
+					continue
+				}
+				ambiguous = winner != nil
+				winner = c.index
+			}
+			if winner == nil {
+				winner = candidates[name][0].index
+				ambiguous = len(candidates[name]) > 1
+			}
+			if !ambiguous {
+				plan[name] = winner
+			}
+		}
+		level = next
+	}
+	return plan
+}
+
+// fieldName returns the attribute name of a struct field: the `rule:"..."` tag, then the
+// `json:"..."` tag, then the Go field name. tagged reports whether a tag supplied the name,
+// and skip is true for fields tagged "-".
+func fieldName(f reflect.StructField) (name string, tagged bool, skip bool) {
+	for _, key := range []string{"rule", "json"} {
+		tag, ok := f.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		name, _, _ = strings.Cut(tag, ",")
+		if name == "-" {
+			return "", false, true
+		}
+		if name != "" {
+			return name, true, false
+		}
+	}
+	return f.Name, false, false
+}
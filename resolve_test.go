@@ -0,0 +1,817 @@
+package rule
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLookupPath(t *testing.T) {
+	doc := map[string]any{
+		"age":      30,
+		"user.raw": "flat",
+		"user": map[string]any{
+			"address": map[string]any{"city": "Berlin"},
+			"tags":    map[string]string{"tier": "gold"},
+		},
+	}
+
+	tests := []struct {
+		path   string
+		want   any
+		wantOk bool
+	}{
+		{"age", 30, true},
+		{"user.raw", "flat", true},
+		{"user.address.city", "Berlin", true},
+		{"user.tags.tier", "gold", true},
+		{"user.address.zip", nil, false},
+		{"age.value", nil, false},
+		{"missing", nil, false},
+	}
+	for _, tt := range tests {
+		got, ok := lookupPath(doc, tt.path)
+		if ok != tt.wantOk || got != tt.want {
+			t.Errorf("lookupPath(%q) = %v, %v; want %v, %v", tt.path, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+func TestEvaluateMap(t *testing.T) {
+	doc := map[string]any{
+		"active":     true,
+		"score":      75,
+		"created_at": "2025-01-01T10:00:00Z",
+		"client_ip":  "10.20.30.40",
+		"expires_at": int64(1767225600),
+		"user": map[string]any{
+			"address": map[string]any{"city": "Berlin"},
+			"nick":    nil,
+		},
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`active eq true and score gt 50`, true},
+		{`user.address.city eq "Berlin"`, true},
+		{`user.address.city eq "Paris"`, false},
+		{`user.address.zip pr`, false},
+		{`not (user.address.zip pr)`, true},
+		{`user.nick pr`, false},
+		{`user.nick eq "x"`, false},
+		{`user.nick gt 3`, false},
+		{`user.nick eq [s]"x"`, false},
+		{`not (user.nick eq "x")`, true},
+		{`user.address pr`, true},
+		{`user.nick eq null`, true},
+		{`missing eq null`, true},
+		{`user.address.city eq null`, false},
+		{`not user.nick eq null`, false},
+		{`user.nick ne null`, false},
+		{`missing ne null`, false},
+		{`user.address ne null`, true},
+		{`missing eq 1 or score le 75`, true},
+		{`score in [7, 5, 750]`, false},
+		{`score in [50, 75]`, true},
+		{`score nin [50, 75]`, false},
+		{`user.address.city in ["Berlin", "Paris"]`, true},
+		{`user.address.city nin ["Ber", "lin"]`, true},
+		{`user.nick nin ["x"]`, false},
+		{`user.address.city mr "^B[a-z]+n$"`, true},
+		{`user.address.city mr "^b"`, false},
+		{`score mr "^7\\d$"`, true},
+		{`not user.address.zip pr`, true},
+		{`NOT user.address PR`, false},
+		{`Not user.address.zip Pr`, true},
+		{`score In [50, 75] and score Nin [1] and score Ge 75 and score Lt 76`, true},
+		{`user.address.city Match "^B" and user.address.city Sw "Be" and user.address.city Co "rl"`, true},
+		{`client_ip Within "10.0.0.0/8" and client_ip Wi "10.0.0.0/8"`, true},
+		{`not user.address.city eq "Paris"`, true},
+		{`not user.nick eq "x"`, true},
+		{`not missing gt 3`, true},
+		{`not active eq true or score eq 75`, true},
+		{`not active eq true and score eq 75`, false},
+		{`not score in [50, 75] or not user.address.city mr "^B"`, false},
+		{`created_at ge [date]"2025-01-01" and created_at lt [t]"2025-01-02T00:00:00+01:00"`, true},
+		{`expires_at gt [t]"2025-06-01T00:00:00Z"`, true},
+		{`expires_at le [date]"2025-06-01"`, false},
+		{`created_at in [t]["2024-12-31T00:00:00Z", "2025-01-01T10:00:00Z"]`, true},
+		{`score gt -3 and score lt -1 or score eq 75`, true},
+		{`client_ip wi ["10.0.0.0/8", "172.16.0.0/12"]`, true},
+		{`client_ip in [cidr]"192.168.0.0/16"`, false},
+		{`client_ip eq [ip]"10.20.30.40" and client_ip gt [ip]"10.20.30.4"`, true},
+		{`not client_ip within "fd00::/8"`, true},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.EvaluateMap(doc)
+		if err != nil {
+			t.Fatalf("EvaluateMap(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateMap(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateMapCaseInsensitive(t *testing.T) {
+	doc := map[string]any{
+		"userName": "Bjørn.Jensen@Example.com",
+		"title":    "Mr",
+		"emails":   "BJENSEN@EXAMPLE.COM",
+		"score":    10,
+	}
+	config := &Config{CaseInsensitive: []string{"userName", "emails", "score"}}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`userName eq "bjørn.jensen@example.com"`, true},
+		{`userName eq "BJØRN.JENSEN@EXAMPLE.COM"`, true},
+		{`userName ne "BJØRN.JENSEN@EXAMPLE.COM"`, false},
+		{`userName co "JENSEN@"`, true},
+		{`userName sw "bjØrn"`, true},
+		{`userName ew ".COM"`, true},
+		{`userName mr "^bj"`, false}, // regular expressions keep their own flags, e.g. (?i)
+		{`userName mr "(?i)^bj"`, true},
+		{`emails in ["a@example.com", "bjensen@example.com"]`, true},
+		{`emails nin ["bjensen@example.com"]`, false},
+		{`emails in "x bjensen@example.com y"`, true},
+		{`score eq 10`, true},
+		{`title eq "mr"`, false}, // not listed in CaseInsensitive
+		{`title eq "Mr"`, true},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, config)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.EvaluateMap(doc)
+		if err != nil {
+			t.Fatalf("EvaluateMap(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateMap(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateAttributeComparison(t *testing.T) {
+	doc := map[string]any{
+		"ordered_at":   time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC),
+		"shipped_at":   "2025-05-03T09:00:00Z",
+		"balance":      int64(1500),
+		"credit_limit": 1000,
+		"owner":        "ada",
+		"user":         map[string]any{"name": "ada", "nick": nil},
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`shipped_at gt ordered_at`, true},
+		{`shipped_at le ordered_at + 1d`, false},
+		{`shipped_at le ordered_at + 2d`, true},
+		{`balance ge credit_limit`, true},
+		{`credit_limit gt balance`, false},
+		{`owner eq user.name and user.name eq owner`, true},
+		{`owner ne user.nick`, false},
+		{`not owner eq missing`, true},
+		{`add(1000, 500) eq balance`, true},
+		{`get_author("Song of Myself") sw echo("Walt")`, true},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, &Config{Functions: newTestRegistry(t)})
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.EvaluateMap(doc)
+		if err != nil {
+			t.Fatalf("EvaluateMap(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateMap(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+	}
+
+	// The right-hand side is a Parameter of its own, listed after the left-hand side
+	r, err := ParseQuery(`a eq 1 and shipped_at gt ordered_at`, nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var names []string
+	for _, p := range r.Params {
+		names = append(names, p.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"a", "shipped_at", "ordered_at"}) {
+		t.Fatalf("Params = %v; want [a shipped_at ordered_at]", names)
+	}
+	got, err := r.Evaluate([]Evaluation{
+		{Param: r.Params[0], Result: int64(1)},
+		{Param: r.Params[1], Result: int64(20)},
+		{Param: r.Params[2], Result: int64(10)},
+	})
+	if err != nil || !got {
+		t.Errorf("Evaluate = %v, %v; want true, nil", got, err)
+	}
+
+	// The referenced value is converted like a literal would be
+	r, err = ParseQuery(`balance gt credit_limit`, nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if _, err := r.EvaluateMap(map[string]any{"balance": int64(1), "credit_limit": "high"}); !errors.Is(err, ErrorInvalidValue) {
+		t.Errorf("EvaluateMap with an unconvertible value: error = %v; want ErrorInvalidValue", err)
+	}
+
+	errorTests := []struct {
+		query   string
+		wantErr error
+	}{
+		{`ts gt today()`, ErrorUnknownFunction},
+		{`get_author(title) eq "x"`, ErrorInvalidFunctionCall},
+		{`sku mr pattern`, ErrorInvalidPattern},
+	}
+	for _, tt := range errorTests {
+		if _, err := ParseQuery(tt.query, &Config{Functions: newTestRegistry(t)}); !errors.Is(err, tt.wantErr) {
+			t.Errorf("ParseQuery(%q) error = %v; want %v", tt.query, err, tt.wantErr)
+		}
+	}
+}
+
+type testAddress struct {
+	City string `json:"city"`
+	Zip  *string
+}
+
+type testAudit struct {
+	CreatedBy string `rule:"created_by"`
+	Version   int    `json:"version"`
+}
+
+type testUser struct {
+	*testAudit
+	Name     string            `rule:"name" json:"full_name"`
+	Age      int               `json:"age,omitempty"`
+	Email    *string           `json:"email"`
+	Address  *testAddress      `json:"address"`
+	Labels   map[string]string `json:"labels"`
+	Secret   string            `rule:"-"`
+	Version  string            `json:"version"`
+	internal int
+}
+
+func TestEvaluateStruct(t *testing.T) {
+	email := "ada@example.com"
+	user := testUser{
+		testAudit: &testAudit{CreatedBy: "admin", Version: 3},
+		Name:      "Ada",
+		Age:       36,
+		Email:     &email,
+		Address:   &testAddress{City: "London"},
+		Labels:    map[string]string{"team": "core"},
+		Secret:    "hunter2",
+		Version:   "v2",
+		internal:  1,
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`name eq "Ada" and age gt 30`, true},
+		{`full_name pr`, false},
+		{`email eq "ada@example.com"`, true},
+		{`address.city eq "London"`, true},
+		{`address.Zip pr`, false},
+		{`labels.team eq "core"`, true},
+		{`created_by eq "admin"`, true},
+		{`version eq "v2"`, true},
+		{`Secret pr`, false},
+		{`internal pr`, false},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		for _, v := range []any{user, &user} {
+			got, err := r.EvaluateStruct(v)
+			if err != nil {
+				t.Fatalf("EvaluateStruct(%q) error: %v", tt.query, err)
+			}
+			if got != tt.want {
+				t.Errorf("EvaluateStruct(%q) with %T = %v; want %v", tt.query, v, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestEvaluateStructNilPointers(t *testing.T) {
+	r, err := ParseQuery(`address.city pr or created_by pr or email pr`, nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	got, err := r.EvaluateStruct(&testUser{})
+	if err != nil {
+		t.Fatalf("evaluate error: %v", err)
+	}
+	if got {
+		t.Errorf("expected false for nil pointers, got true")
+	}
+}
+
+func TestEvaluateStructNilLeafPointer(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`email eq "x"`, false},
+		{`email ne "x"`, false},
+		{`email co "x"`, false},
+		{`not (email eq "x")`, true},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.EvaluateStruct(testUser{})
+		if err != nil {
+			t.Fatalf("EvaluateStruct(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateStruct(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateNullAgainstComposites(t *testing.T) {
+	doc := map[string]any{
+		"address":  testAddress{City: "London"},
+		"contacts": []map[string]any{{"email": "ada@example.com"}},
+		"labels":   map[string]any{"team": "core"},
+		"matrix":   [][]int{{1, 2}, {3}},
+		"tags":     []string{"a", "b"},
+	}
+	user := testUser{Address: &testAddress{City: "London"}, Labels: map[string]string{"team": "core"}}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`address eq null`, false},
+		{`address ne null`, true},
+		{`labels eq null`, false},
+		{`labels ne null`, true},
+		{`not labels eq null`, true},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.EvaluateMap(doc)
+		if err != nil {
+			t.Fatalf("EvaluateMap(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateMap(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+		got, err = r.EvaluateStruct(user)
+		if err != nil {
+			t.Fatalf("EvaluateStruct(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateStruct(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+	}
+
+	// Lists, and elements of lists, only exist in the map document
+	listTests := []struct {
+		query string
+		want  bool
+	}{
+		{`tags eq null`, false},
+		{`tags ne null`, true},
+		{`any(contacts) eq null`, false},
+		{`all(contacts) ne null`, true},
+		{`none(matrix) eq null`, true},
+		{`any(matrix) ne null`, true},
+	}
+	for _, tt := range listTests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.EvaluateMap(doc)
+		if err != nil {
+			t.Fatalf("EvaluateMap(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateMap(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+type testEmbedA struct {
+	X int
+	Y int
+	Z int `rule:"z"`
+}
+
+type testEmbedB struct {
+	X int
+	Y int `json:"Y"`
+	Z int `rule:"z"`
+}
+
+type testEmbedded struct {
+	testEmbedA
+	testEmbedB
+}
+
+func TestBuildStructPlanConflicts(t *testing.T) {
+	plan := buildStructPlan(reflect.TypeOf(testEmbedded{}))
+	if _, ok := plan["X"]; ok {
+		t.Errorf("X is ambiguous and should be dropped, got %v", plan["X"])
+	}
+	if got, want := plan["Y"], []int{1, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Y = %v; want the tagged field %v", got, want)
+	}
+	if _, ok := plan["z"]; ok {
+		t.Errorf("z is tagged twice and should be dropped, got %v", plan["z"])
+	}
+
+	r, err := ParseQuery(`X pr or Y eq 2`, nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	got, err := r.EvaluateStruct(testEmbedded{testEmbedA{X: 1, Y: 1}, testEmbedB{X: 2, Y: 2}})
+	if err != nil || !got {
+		t.Errorf("EvaluateStruct = %v, %v; want true, nil", got, err)
+	}
+}
+
+func TestEvaluateStructInvalid(t *testing.T) {
+	r, err := ParseQuery(`age gt 1`, nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var nilUser *testUser
+	for _, v := range []any{nil, 42, map[string]any{"age": 2}, nilUser} {
+		if _, err := r.EvaluateStruct(v); !errors.Is(err, ErrorInvalidStruct) {
+			t.Errorf("EvaluateStruct(%T) error = %v; want ErrorInvalidStruct", v, err)
+		}
+	}
+}
+
+func BenchmarkEvaluateStruct(b *testing.B) {
+	r, err := ParseQuery(`name eq "Ada" and address.city eq "London" and created_by pr`, nil)
+	if err != nil {
+		b.Fatalf("ParseQuery error: %v", err)
+	}
+	user := &testUser{
+		testAudit: &testAudit{CreatedBy: "admin"},
+		Name:      "Ada",
+		Address:   &testAddress{City: "London"},
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := r.EvaluateStruct(user); err != nil {
+			b.Fatalf("EvaluateStruct error: %v", err)
+		}
+	}
+}
+
+// countingResolver serves values from a map and records how often each attribute was resolved.
+type countingResolver struct {
+	values map[string]any
+	calls  map[string]int
+}
+
+func (c *countingResolver) Resolve(_ context.Context, param Parameter) (any, error) {
+	c.calls[param.Name]++
+	if param.Name == "broken" {
+		return nil, errors.New("backend unavailable")
+	}
+	val, ok := c.values[param.Name]
+	if !ok {
+		return nil, ErrorParameterNotFound
+	}
+	return val, nil
+}
+
+func TestEvaluateResolver(t *testing.T) {
+	tests := []struct {
+		query     string
+		want      bool
+		wantErr   bool
+		wantCalls map[string]int
+	}{
+		{`age gt 18 and broken eq 1`, false, false, map[string]int{"age": 1}},
+		{`age gt 18 or name eq "Ada"`, true, false, map[string]int{"age": 1, "name": 1}},
+		{`name eq "Ada" or broken eq 1`, true, false, map[string]int{"name": 1}},
+		{`age ge 10 and age le 12 and not (age eq 10)`, false, false, map[string]int{"age": 1}},
+		{`missing pr or (missing eq 1 or age eq 10)`, true, false, map[string]int{"missing": 1, "age": 1}},
+		{`age eq 10 and broken eq 1`, false, true, map[string]int{"age": 1, "broken": 1}},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		res := &countingResolver{
+			values: map[string]any{"age": 10, "name": "Ada"},
+			calls:  map[string]int{},
+		}
+		got, err := r.EvaluateResolver(res)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("EvaluateResolver(%q) error = %v; wantErr %v", tt.query, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateResolver(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+		if !reflect.DeepEqual(res.calls, tt.wantCalls) {
+			t.Errorf("EvaluateResolver(%q) resolved %v; want %v", tt.query, res.calls, tt.wantCalls)
+		}
+	}
+}
+
+func TestEvaluateResolverFunctionCalls(t *testing.T) {
+	calls := 0
+	res := ResolverFunc(func(_ context.Context, param Parameter) (any, error) {
+		calls++
+		if param.InputType != FunctionCall {
+			return nil, ErrorParameterNotFound
+		}
+		return param.FunctionArguments[0].Value.(int64) * 2, nil
+	})
+
+	r, err := ParseQuery(`double(2) eq 4 and double(2) ne 5 and double(3) eq 6`, nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	got, err := r.EvaluateResolver(res)
+	if err != nil || !got {
+		t.Fatalf("EvaluateResolver = %v, %v; want true, nil", got, err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 resolver calls (double(2) memoized), got %d", calls)
+	}
+}
+
+func TestEvaluateResolverNotFunctionCall(t *testing.T) {
+	res := ResolverFunc(func(_ context.Context, param Parameter) (any, error) {
+		return param.FunctionArguments[0].Value.(int64) * 2, nil
+	})
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`not double(2) eq 4`, false},
+		{`not double(2) eq 5`, true},
+		{`NOT double(1) eq 2 or double(3) eq 6`, true},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.EvaluateResolver(res)
+		if err != nil {
+			t.Fatalf("EvaluateResolver(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateResolver(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateValuePath(t *testing.T) {
+	type email struct {
+		Type    string `json:"type"`
+		Value   string `json:"value"`
+		Primary bool   `json:"primary"`
+	}
+	type account struct {
+		Emails []email   `json:"emails"`
+		Tags   [2]string `json:"tags"`
+	}
+
+	doc := map[string]any{
+		"emails": []any{
+			map[string]any{"type": "home", "value": "ada@home.org"},
+			map[string]any{"type": "work", "value": "ada@example.com", "primary": true},
+		},
+		"tags": []string{"go", "rules"},
+		"groups": []map[string]any{
+			{"name": "admins", "members": []string{"root"}},
+			{"name": "devs", "members": []string{"ada", "bob"}},
+		},
+		"manager": map[string]any{"type": "direct", "value": "grace"},
+	}
+	acct := account{
+		Emails: []email{{Type: "home", Value: "ada@home.org"}, {Type: "work", Value: "ada@example.com", Primary: true}},
+		Tags:   [2]string{"go", "rules"},
+	}
+
+	tests := []struct {
+		query     string
+		want      bool
+		structToo bool
+	}{
+		{`emails[type eq "work" and value ew "@example.com"]`, true, true},
+		{`emails[type eq "home" and value ew "@example.com"]`, false, true},
+		{`emails[type eq "home" or primary eq true]`, true, true},
+		{`emails[primary eq true and not (type eq "home")]`, true, true},
+		{`not emails[type eq "other"]`, true, true},
+		{`emails[type eq "work"] and tags[value eq "go"]`, true, true},
+		{`tags[value sw "ru"]`, true, true},
+		{`tags[value eq "java"]`, false, true},
+		{`emails[display pr]`, false, true},
+		{`phones[type eq "work"]`, false, false},
+		{`not phones[type eq "work"]`, true, false},
+		{`groups[name eq "devs" and members[value eq "ada"]]`, true, false},
+		{`groups[name eq "admins" and members[value eq "ada"]]`, false, false},
+		{`manager[type eq "direct"]`, true, false},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.EvaluateMap(doc)
+		if err != nil {
+			t.Fatalf("EvaluateMap(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateMap(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+		if !tt.structToo {
+			continue
+		}
+		got, err = r.EvaluateStruct(acct)
+		if err != nil {
+			t.Fatalf("EvaluateStruct(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateStruct(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+	}
+
+	// The filter is described by the Parameter of the multi-valued attribute
+	r, err := ParseQuery(`emails[type eq "work" and value ew "@EXAMPLE.com"] and active eq true`, &Config{
+		CaseInsensitive: []string{"emails.value"},
+	})
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if len(r.Params) != 2 || r.Params[0].Name != "emails" || r.Params[1].Name != "active" {
+		t.Fatalf("Params = %+v; want emails, active", r.Params)
+	}
+	filter := r.Params[0].Filter
+	if filter == nil || len(filter.Params) != 2 || filter.Params[0].Name != "type" || filter.Params[1].Name != "value" {
+		t.Fatalf("Params[0].Filter = %+v; want the conditions on type and value", filter)
+	}
+	if r.Params[1].Filter != nil {
+		t.Errorf("Params[1].Filter = %+v; want nil", r.Params[1].Filter)
+	}
+
+	// Evaluate takes the elements as the value of the multi-valued attribute
+	got, err := r.Evaluate([]Evaluation{
+		{Param: r.Params[0], Result: acct.Emails},
+		{Param: r.Params[1], Result: true},
+	})
+	if err != nil || !got {
+		t.Errorf("Evaluate = %v, %v; want true, nil", got, err)
+	}
+
+	functions := NewFunctionRegistry()
+	if err := functions.Register("domain", func(args ...any) (any, error) { return "@example.com", nil }); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	r, err = ParseQuery(`emails[value ew domain()]`, &Config{Functions: functions})
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if got, err := r.EvaluateMap(doc); err != nil || !got {
+		t.Errorf("EvaluateMap with a function in the filter = %v, %v; want true, nil", got, err)
+	}
+
+	errorTests := []struct {
+		query   string
+		wantErr error
+	}{
+		{`emails[type eq "work"`, ErrorSyntaxError},
+		{`emails[]`, ErrorSyntaxError},
+		{`emails()[type eq "work"]`, ErrorSyntaxError},
+		{`emails[value ew other()]`, ErrorUnknownFunction},
+		{`emails[value mr "("]`, ErrorInvalidPattern},
+	}
+	for _, tt := range errorTests {
+		if _, err := ParseQuery(tt.query, &Config{Functions: functions}); !errors.Is(err, tt.wantErr) {
+			t.Errorf("ParseQuery(%q) error = %v; want %v", tt.query, err, tt.wantErr)
+		}
+	}
+}
+
+func TestEvaluateQuantifiers(t *testing.T) {
+	doc := map[string]any{
+		"tags":   []string{"new", "vip"},
+		"roles":  []any{"user", "editor"},
+		"scores": []int{72, 50, 91},
+		"items":  []map[string]any{{"sku": "a"}, {"sku": "b"}, {"sku": "c"}, {"sku": "d"}},
+		"name":   "Ada",
+		"prefs":  map[string]any{"lang": "en"},
+		"order":  map[string]any{"lines": []float64{9.5, 12}},
+		"all":    "attribute",
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`any(tags) eq "vip"`, true},
+		{`any(tags) eq "banned"`, false},
+		{`all(scores) ge 50`, true},
+		{`all(scores) gt 50`, false},
+		{`none(roles) eq "banned"`, true},
+		{`none(roles) eq "editor"`, false},
+		{`not any(tags) eq "vip"`, false},
+		{`any(roles) in ["admin", "editor"]`, true},
+		{`any(tags) sw "v"`, true},
+		{`all(order.lines) lt 20`, true},
+		{`len(items) gt 3`, true},
+		{`len(items) gt 4`, false},
+		{`len(tags) eq 2 and len(name) eq 3 and len(prefs) eq 1`, true},
+		{`len(tags) lt len(items)`, true},
+		{`len(scores) * 10 eq 30`, true},
+		{`(any(tags) eq "vip")`, true},
+		{`any(missing) eq "x"`, false},
+		{`any(missing) eq null`, false},
+		{`len(missing) ge 0`, false},
+		{`all eq "attribute" and len(all) eq 9`, true},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.EvaluateMap(doc)
+		if err != nil {
+			t.Fatalf("EvaluateMap(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateMap(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+	}
+
+	// The quantified attribute is an ordinary Parameter, so Evaluate takes its elements
+	r, err := ParseQuery(`any(tags) eq "VIP" and len(roles) le 2`, &Config{CaseInsensitive: []string{"tags"}})
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if len(r.Params) != 2 || r.Params[0].Name != "tags" || r.Params[1].Name != "roles" {
+		t.Fatalf("Params = %+v; want tags, roles", r.Params)
+	}
+	got, err := r.Evaluate([]Evaluation{
+		{Param: r.Params[0], Result: []any{"new", "vip"}},
+		{Param: r.Params[1], Result: []string{"user"}},
+	})
+	if err != nil || !got {
+		t.Errorf("Evaluate = %v, %v; want true, nil", got, err)
+	}
+
+	r, err = ParseQuery(`len(count) gt 1`, nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if _, err := r.EvaluateMap(map[string]any{"count": 3}); !errors.Is(err, ErrorTypeMismatch) {
+		t.Errorf("len of an int: error = %v; want ErrorTypeMismatch", err)
+	}
+
+	errorTests := []string{
+		`tags eq any(roles)`,
+		`any(tags) + 1 eq 2`,
+		`len(now()) gt 1`,
+		`any(tags)`,
+		`any tags eq "x"`,
+	}
+	for _, query := range errorTests {
+		if _, err := ParseQuery(query, nil); !errors.Is(err, ErrorSyntaxError) {
+			t.Errorf("ParseQuery(%q) error = %v; want ErrorSyntaxError", query, err)
+		}
+	}
+}
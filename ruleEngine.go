@@ -1,38 +1,129 @@
 package rule
 
 import (
+	"context"
 	"github.com/antlr4-go/antlr/v4"
 	parser "github.com/sky93/go-rule/internal/antlr4"
+	"reflect"
+	"time"
 )
 
 // Evaluate applies the stored exprTree logic to a slice of Evaluation structs.
 // Each Evaluation links a Parameter in g.Params to a real runtime value. The result is a bool.
+//
+// FunctionCall parameters without an Evaluation are computed by the FunctionRegistry given
+// in Config.Functions, if any.
 func (g *Rule) Evaluate(values []Evaluation) (bool, error) {
+	return g.EvaluateContext(context.Background(), values)
+}
+
+// EvaluateContext is like Evaluate, but threads ctx through the evaluation: it is handed to
+// functions registered with FunctionRegistry.RegisterContext (and to the Resolver for
+// EvaluateResolverContext). Once ctx is canceled or its deadline passes, no further values are
+// resolved and the evaluation fails with an error wrapping both ErrorEvaluationAborted and ctx.Err().
+func (g *Rule) EvaluateContext(ctx context.Context, values []Evaluation) (bool, error) {
 	valuesMap := make(map[int]any)
 	for _, value := range values {
 		valuesMap[value.Param.id] = value.Result
 	}
-	return g.exprTree.evaluate(valuesMap, g.debugMode)
+	return g.evaluate(&evalContext{ctx: ctx, values: valuesMap})
+}
+
+// EvaluateMap applies the Rule to a document of attribute values, such as a decoded JSON object.
+// Each Parameter name is resolved by walking doc, so a subAttr path like "user.address.city"
+// reads doc["user"]["address"]["city"]. Names that cannot be resolved are treated as missing,
+// exactly like a Parameter left out of Evaluate (so "pr" reports false).
+func (g *Rule) EvaluateMap(doc map[string]any) (bool, error) {
+	return g.EvaluateMapContext(context.Background(), doc)
+}
+
+// EvaluateMapContext is like EvaluateMap, with cancellation as described for EvaluateContext.
+func (g *Rule) EvaluateMapContext(ctx context.Context, doc map[string]any) (bool, error) {
+	return g.EvaluateResolverContext(ctx, documentResolver{root: doc})
+}
+
+// EvaluateStruct applies the Rule to a struct (or a pointer to one). Attribute names are matched
+// against exported fields using the `rule:"name"` tag, then the `json:"name"` tag, then the field
+// name itself. Pointers, embedded structs and nested structs or maps are followed for subAttr
+// paths; a nil pointer along the path makes the attribute missing.
+//
+// ErrorInvalidStruct is returned if v is not a struct or a non-nil pointer to a struct.
+func (g *Rule) EvaluateStruct(v any) (bool, error) {
+	return g.EvaluateStructContext(context.Background(), v)
+}
+
+// EvaluateStructContext is like EvaluateStruct, with cancellation as described for EvaluateContext.
+func (g *Rule) EvaluateStructContext(ctx context.Context, v any) (bool, error) {
+	rv, ok := indirect(reflect.ValueOf(v))
+	if !ok || rv.Kind() != reflect.Struct {
+		return false, ErrorInvalidStruct
+	}
+	return g.EvaluateResolverContext(ctx, documentResolver{root: v})
+}
+
+// EvaluateResolver applies the Rule, asking r for parameter values only when they are needed.
+// See Resolver for the short-circuit and memoization guarantees.
+func (g *Rule) EvaluateResolver(r Resolver) (bool, error) {
+	return g.EvaluateResolverContext(context.Background(), r)
+}
+
+// EvaluateResolverContext is like EvaluateResolver, with cancellation as described for
+// EvaluateContext. ctx is handed to r as well. r may be nil if all values come from
+// registered functions.
+func (g *Rule) EvaluateResolverContext(ctx context.Context, r Resolver) (bool, error) {
+	return g.evaluate(&evalContext{ctx: ctx, resolver: r})
+}
+
+// evaluate fills in the Rule-wide settings of ec and evaluates the expression tree.
+// FunctionCall parameters without a supplied value are computed by the Rule's FunctionRegistry, if any.
+func (g *Rule) evaluate(ec *evalContext) (bool, error) {
+	if ec.ctx == nil {
+		ec.ctx = context.Background()
+	}
+	ec.functions = g.functions
+	ec.debugMode = g.debugMode
+	ec.clock = g.clock
+	if ec.clock == nil {
+		ec.clock = time.Now
+	}
+	return g.exprTree.evaluate(ec)
 }
 
 // ParseQuery takes a SCIM-like query (e.g. `age gt 30 and (lang eq "en" or lang eq "fr")`) and
-// compiles it into a Rule object. Optional config can enable DebugMode.
+// compiles it into a Rule object. Optional config can enable DebugMode and supply a FunctionRegistry.
 //
-// If parsing fails due to syntax errors or other issues, an error is returned.
+// If parsing fails due to syntax errors or other issues, an error is returned. With a
+// FunctionRegistry, calls to unknown functions or calls that do not match the registered
+// signature are rejected here as well. The patterns of regular expression matches (mr) are
+// compiled here too, so an invalid pattern fails with ErrorInvalidPattern.
 // Otherwise, the returned Rule can be used for Evaluate().
 func ParseQuery(input string, config *Config) (Rule, error) {
 	debugMode := false
 	if config != nil && config.DebugMode {
 		debugMode = true
 	}
+	var functions *FunctionRegistry
+	var clock func() time.Time
+	maxPatternLength := 0
+	caseInsensitive := make(map[string]bool)
+	if config != nil {
+		functions = config.Functions
+		clock = config.Now
+		maxPatternLength = config.MaxPatternLength
+		for _, name := range config.CaseInsensitive {
+			caseInsensitive[name] = true
+		}
+	}
 
 	is := antlr.NewInputStream(input)
 	lexer := parser.NewSCIMQueryLexer(is)
 	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
 	p := parser.NewSCIMQueryParser(stream)
 
-	// Attach a custom error listener to catch syntax errors
+	// Attach a custom error listener to catch syntax errors, including characters the lexer rejects
 	errListener := &errorListener{}
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(errListener)
 	p.RemoveErrorListeners()
 	p.AddErrorListener(errListener)
 
@@ -43,16 +134,28 @@ func ParseQuery(input string, config *Config) (Rule, error) {
 	}
 
 	// Build internal expression tree
-	vis := &queryVisitor{}
+	vis := &queryVisitor{
+		maxPatternLength: maxPatternLength,
+		caseInsensitive:  caseInsensitive,
+		leftToRight:      config != nil && config.LeftToRightLogic,
+	}
 	exprAny, err := vis.visitRoot(tree.(*parser.RootContext))
 	if err != nil {
 		return Rule{}, err
 	}
 
+	if functions != nil {
+		if err := functions.validateAll(vis.parameters); err != nil {
+			return Rule{}, err
+		}
+	}
+
 	expr, _ := exprAny.(*exprTree)
 	return Rule{
 		exprTree:  *expr,
 		Params:    vis.parameters,
 		debugMode: debugMode,
+		functions: functions,
+		clock:     clock,
 	}, err
 }
@@ -1,10 +1,13 @@
 package rule
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/shopspring/decimal"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestParseQueryAndEvaluate is a higher-level “integration” style test
@@ -446,3 +449,217 @@ func BenchmarkEvaluate(b *testing.B) {
 		}
 	}
 }
+
+func TestEvaluateResolverContextCanceled(t *testing.T) {
+	r, err := ParseQuery(`age gt 18`, nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	resolved := false
+	res := ResolverFunc(func(_ context.Context, _ Parameter) (any, error) {
+		resolved = true
+		return 20, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = r.EvaluateResolverContext(ctx, res)
+	if !errors.Is(err, ErrorEvaluationAborted) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected aborted/canceled error, got %v", err)
+	}
+	if resolved {
+		t.Errorf("resolver should not be called after cancellation")
+	}
+}
+
+func TestEvaluateContextDeadline(t *testing.T) {
+	functions := NewFunctionRegistry()
+	err := functions.RegisterContext("slow_score", func(ctx context.Context, args ...any) (any, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return 100, nil
+		}
+	})
+	if err != nil {
+		t.Fatalf("RegisterContext error: %v", err)
+	}
+	r, err := ParseQuery(`slow_score() gt 50 or age gt 18`, &Config{Functions: functions})
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = r.EvaluateContext(ctx, nil)
+	if !errors.Is(err, ErrorEvaluationAborted) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected aborted/deadline error, got %v", err)
+	}
+}
+
+func TestEvaluateResolverContextPassesContext(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "tenant-1")
+
+	functions := NewFunctionRegistry()
+	err := functions.RegisterContext("tenant", func(ctx context.Context, args ...any) (any, error) {
+		return ctx.Value(ctxKey{}), nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterContext error: %v", err)
+	}
+	r, err := ParseQuery(`tenant() eq "tenant-1" and region eq "eu"`, &Config{Functions: functions})
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	res := ResolverFunc(func(ctx context.Context, p Parameter) (any, error) {
+		if ctx.Value(ctxKey{}) != "tenant-1" {
+			return nil, errors.New("context not passed to resolver")
+		}
+		return "eu", nil
+	})
+	got, err := r.EvaluateResolverContext(ctx, res)
+	if err != nil || !got {
+		t.Fatalf("EvaluateResolverContext = %v, %v; want true, nil", got, err)
+	}
+}
+
+func TestEvaluateDocumentContext(t *testing.T) {
+	functions := NewFunctionRegistry()
+	err := functions.RegisterContext("quota", func(ctx context.Context, args ...any) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("RegisterContext error: %v", err)
+	}
+	r, err := ParseQuery(`age gt 18 and quota() gt 0`, &Config{Functions: functions})
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	type person struct {
+		Age int `json:"age"`
+	}
+	evaluations := map[string]func(ctx context.Context) (bool, error){
+		"EvaluateContext": func(ctx context.Context) (bool, error) {
+			return r.EvaluateContext(ctx, []Evaluation{{Param: r.Params[0], Result: 20}})
+		},
+		"EvaluateMapContext": func(ctx context.Context) (bool, error) {
+			return r.EvaluateMapContext(ctx, map[string]any{"age": 20})
+		},
+		"EvaluateStructContext": func(ctx context.Context) (bool, error) {
+			return r.EvaluateStructContext(ctx, person{Age: 20})
+		},
+	}
+	for name, evaluate := range evaluations {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err := evaluate(ctx)
+		cancel()
+		if !errors.Is(err, ErrorEvaluationAborted) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%s: expected aborted/deadline error, got %v", name, err)
+		}
+	}
+}
+
+func TestLogicalPrecedence(t *testing.T) {
+	doc := map[string]any{"a": 1, "b": 0, "c": 0}
+
+	tests := []struct {
+		query       string
+		leftToRight bool
+		want        bool
+	}{
+		// "and" binds tighter than "or": a eq 1 or (b eq 2 and c eq 3)
+		{`a eq 1 or b eq 2 and c eq 3`, false, true},
+		{`a eq 1 OR b eq 2 AND c eq 3`, false, true},
+		{`a Eq 1 Or b EQ 2 And c eQ 3`, false, true},
+		{`Not (a eq 1 oR b eq 2) aNd c eq 3`, false, false},
+		{`b eq 2 and c eq 3 or a eq 1`, false, true},
+		{`(a eq 1 or b eq 2) and c eq 3`, false, false},
+		{`a eq 2 or b eq 0 and c eq 0 or c eq 5`, false, true},
+		{`a eq 2 or b eq 0 and c eq 5`, false, false},
+
+		// Legacy grouping: (a eq 1 or b eq 2) and c eq 3
+		{`a eq 1 or b eq 2 and c eq 3`, true, false},
+		{`b eq 2 and c eq 3 or a eq 1`, true, true},
+		{`a eq 1 or (b eq 2 and c eq 3)`, true, true},
+		{`not (a eq 1 or b eq 2 and c eq 3) or a eq 1`, true, true},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, &Config{LeftToRightLogic: tt.leftToRight})
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.EvaluateMap(doc)
+		if err != nil {
+			t.Fatalf("EvaluateMap(%q) error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateMap(%q, leftToRight=%v) = %v; want %v", tt.query, tt.leftToRight, got, tt.want)
+		}
+	}
+
+	// Parameters keep their source order in both modes
+	for _, leftToRight := range []bool{false, true} {
+		r, err := ParseQuery(`a eq 1 or b eq 2 and c eq 3`, &Config{LeftToRightLogic: leftToRight})
+		if err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		for i, name := range []string{"a", "b", "c"} {
+			if r.Params[i].Name != name {
+				t.Errorf("leftToRight=%v: Params[%d].Name = %q; want %q", leftToRight, i, r.Params[i].Name, name)
+			}
+		}
+	}
+}
+
+func TestFlexibleWhitespace(t *testing.T) {
+	doc := map[string]any{"age": 5, "name": "bob"}
+
+	queries := []string{
+		`age  gt 3`,
+		"age\tgt\t3",
+		"age gt 3\r\nand name eq \"bob\"",
+		"\n  (\n    age gt 3\n    and name eq \"bob\"\n  )\n",
+		`not(age lt 3)`,
+		` ( age gt 3 )or( name eq "x" ) `,
+		`age in [1 ,  5,	7]`,
+		`name in [ "alice",` + "\n" + `"bob" ]`,
+	}
+	for _, q := range queries {
+		r, err := ParseQuery(q, nil)
+		if err != nil {
+			t.Errorf("ParseQuery(%q) error: %v", q, err)
+			continue
+		}
+		got, err := r.EvaluateMap(doc)
+		if err != nil || !got {
+			t.Errorf("EvaluateMap(%q) = %v, %v; want true, nil", q, got, err)
+		}
+	}
+
+	r, err := ParseQuery("f( \"a\" ,\t3 )\r\n eq 3", nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if args := r.Params[0].FunctionArguments; len(args) != 2 || args[0].Value != "a" || args[1].Value != int64(3) {
+		t.Errorf("FunctionArguments = %+v; want \"a\" and 3", args)
+	}
+
+	errorTests := []struct {
+		query   string
+		wantPos string
+	}{
+		{"age gt 3\r\n\tand name eq", "line 2:12:"},
+		{"age gt 3 and\n  name ?? \"bob\"", "line 2:7:"},
+		{"age\tgt 3 and name eq \"bob\" #", "line 1:27:"},
+	}
+	for _, tt := range errorTests {
+		_, err := ParseQuery(tt.query, nil)
+		if !errors.Is(err, ErrorSyntaxError) || !strings.Contains(err.Error(), tt.wantPos) {
+			t.Errorf("ParseQuery(%q) error = %v; want a syntax error %q", tt.query, err, tt.wantPos)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package rule
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Semver is a semantic version as defined by SemVer 2.0 (https://semver.org), e.g. 2.10.0-rc.1+build.5.
+// It is the value of a [semver] annotation, and versions compare by their precedence.
+type Semver struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	PreRelease []string // dot-separated pre-release identifiers, e.g. ["rc", "1"]
+	Build      string   // build metadata, ignored for precedence
+}
+
+// ParseSemver parses a version such as "1.2.3", "2.0.0-beta.2" or "1.0.0+20250101". A leading "v",
+// as in "v1.2.3", is accepted. A malformed version returns an error wrapping ErrorInvalidSemver
+// that explains what is wrong with it.
+func ParseSemver(s string) (Semver, error) {
+	var v Semver
+	rest := strings.TrimPrefix(s, "v")
+
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		v.Build = rest[i+1:]
+		rest = rest[:i]
+		for _, id := range strings.Split(v.Build, ".") {
+			if !isSemverIdentifier(id) {
+				return Semver{}, newErrorInvalidSemver(s, "build metadata must be non-empty dot-separated identifiers of [0-9A-Za-z-]")
+			}
+		}
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		v.PreRelease = strings.Split(rest[i+1:], ".")
+		rest = rest[:i]
+		for _, id := range v.PreRelease {
+			if !isSemverIdentifier(id) {
+				return Semver{}, newErrorInvalidSemver(s, "pre-release must be non-empty dot-separated identifiers of [0-9A-Za-z-]")
+			}
+			if isSemverNumeric(id) && len(id) > 1 && id[0] == '0' {
+				return Semver{}, newErrorInvalidSemver(s, "numeric pre-release identifier "+id+" has a leading zero")
+			}
+		}
+	}
+
+	core := strings.Split(rest, ".")
+	if len(core) != 3 {
+		return Semver{}, newErrorInvalidSemver(s, "want MAJOR.MINOR.PATCH")
+	}
+	for i, part := range core {
+		if !isSemverNumeric(part) {
+			return Semver{}, newErrorInvalidSemver(s, "version number "+strconv.Quote(part)+" is not a non-negative integer")
+		}
+		if len(part) > 1 && part[0] == '0' {
+			return Semver{}, newErrorInvalidSemver(s, "version number "+part+" has a leading zero")
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return Semver{}, newErrorInvalidSemver(s, "version number "+part+" is too large")
+		}
+		switch i {
+		case 0:
+			v.Major = n
+		case 1:
+			v.Minor = n
+		default:
+			v.Patch = n
+		}
+	}
+	return v, nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether v has a lower, equal or higher precedence than w.
+// Pre-releases precede the release (1.0.0-rc.1 < 1.0.0); build metadata is ignored.
+func (v Semver) Compare(w Semver) int {
+	for _, c := range [][2]uint64{{v.Major, w.Major}, {v.Minor, w.Minor}, {v.Patch, w.Patch}} {
+		if c[0] != c[1] {
+			if c[0] < c[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case len(v.PreRelease) == 0 && len(w.PreRelease) == 0:
+		return 0
+	case len(v.PreRelease) == 0:
+		return 1
+	case len(w.PreRelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.PreRelease) && i < len(w.PreRelease); i++ {
+		if c := comparePreRelease(v.PreRelease[i], w.PreRelease[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.PreRelease) < len(w.PreRelease):
+		return -1
+	case len(v.PreRelease) > len(w.PreRelease):
+		return 1
+	}
+	return 0
+}
+
+// String returns the version in its canonical form, e.g. "1.2.3-rc.1+build.5".
+func (v Semver) String() string {
+	var sb strings.Builder
+	sb.WriteString(strconv.FormatUint(v.Major, 10))
+	sb.WriteByte('.')
+	sb.WriteString(strconv.FormatUint(v.Minor, 10))
+	sb.WriteByte('.')
+	sb.WriteString(strconv.FormatUint(v.Patch, 10))
+	if len(v.PreRelease) > 0 {
+		sb.WriteByte('-')
+		sb.WriteString(strings.Join(v.PreRelease, "."))
+	}
+	if v.Build != "" {
+		sb.WriteByte('+')
+		sb.WriteString(v.Build)
+	}
+	return sb.String()
+}
+
+// comparePreRelease compares two pre-release identifiers: numeric identifiers compare numerically
+// and have a lower precedence than alphanumeric ones, which compare in ASCII order.
+func comparePreRelease(a, b string) int {
+	aNum, bNum := isSemverNumeric(a), isSemverNumeric(b)
+	switch {
+	case aNum && bNum:
+		// Without leading zeros, a longer number is a larger one
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// isSemverIdentifier reports whether id is a non-empty string of ASCII alphanumerics and hyphens.
+func isSemverIdentifier(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, c := range []byte(id) {
+		if !(c == '-' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// isSemverNumeric reports whether id is a non-empty string of ASCII digits.
+func isSemverNumeric(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, c := range []byte(id) {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// toSemver converts a Semver or a version string (see ParseSemver) to a Semver.
+func toSemver(v any) (Semver, bool) {
+	switch s := v.(type) {
+	case Semver:
+		return s, true
+	case string:
+		parsed, err := ParseSemver(s)
+		return parsed, err == nil
+	}
+	return Semver{}, false
+}
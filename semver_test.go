@@ -0,0 +1,120 @@
+package rule
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSemver(t *testing.T) {
+	valid := []struct {
+		in   string
+		want string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"v2.10.0", "2.10.0"},
+		{"0.0.0", "0.0.0"},
+		{"1.0.0-alpha.1", "1.0.0-alpha.1"},
+		{"1.0.0-0.3.7", "1.0.0-0.3.7"},
+		{"1.0.0-x-y-z.--", "1.0.0-x-y-z.--"},
+		{"1.0.0+20130313144700", "1.0.0+20130313144700"},
+		{"1.0.0-beta+exp.sha.5114f85", "1.0.0-beta+exp.sha.5114f85"},
+		{"1.0.0+0.build.01", "1.0.0+0.build.01"},
+	}
+	for _, tt := range valid {
+		v, err := ParseSemver(tt.in)
+		if err != nil {
+			t.Errorf("ParseSemver(%q) error: %v", tt.in, err)
+			continue
+		}
+		if v.String() != tt.want {
+			t.Errorf("ParseSemver(%q) = %s; want %s", tt.in, v, tt.want)
+		}
+	}
+
+	invalid := []string{
+		"", "1", "1.2", "1.2.3.4", "01.2.3", "1.02.3", "1.2.03", "a.b.c", "1.2.-3",
+		"1.2.3-", "1.2.3-01", "1.2.3-alpha..1", "1.2.3-alpha_1", "1.2.3+", "1.2.3+a..b",
+		"99999999999999999999.0.0",
+	}
+	for _, in := range invalid {
+		if _, err := ParseSemver(in); !errors.Is(err, ErrorInvalidSemver) {
+			t.Errorf("ParseSemver(%q) error = %v; want ErrorInvalidSemver", in, err)
+		}
+	}
+}
+
+func TestSemverCompare(t *testing.T) {
+	// Precedence example from the SemVer 2.0 specification, in ascending order
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "2.0.0", "2.1.0", "2.1.1", "2.9.0", "2.10.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, b := mustParseSemver(t, ordered[i]), mustParseSemver(t, ordered[j])
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if got := a.Compare(b); got != want {
+				t.Errorf("%s.Compare(%s) = %d; want %d", a, b, got, want)
+			}
+		}
+	}
+
+	if a, b := mustParseSemver(t, "1.0.0+build.1"), mustParseSemver(t, "1.0.0+build.2"); a.Compare(b) != 0 {
+		t.Errorf("build metadata must not affect precedence")
+	}
+}
+
+func TestEvaluateSemver(t *testing.T) {
+	tests := []struct {
+		query   string
+		version any
+		want    bool
+	}{
+		{`app_version ge [semver]"2.10.0"`, "2.9.0", false},
+		{`app_version ge [semver]"2.10.0"`, "2.10.0", true},
+		{`app_version ge [semver]"2.10.0"`, "v2.10.1", true},
+		{`app_version lt [semver]"2.10.0"`, "2.10.0-rc.1", true},
+		{`app_version eq [semver]"2.10.0"`, "2.10.0+ios", true},
+		{`app_version in [semver]["1.0.0", "2.0.0"]`, "2.0.0", true},
+		{`app_version gt [semver]"1.0.0"`, mustParseSemver(t, "1.0.1"), true},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		got, err := r.EvaluateMap(map[string]any{"app_version": tt.version})
+		if err != nil {
+			t.Fatalf("EvaluateMap(%q, %v) error: %v", tt.query, tt.version, err)
+		}
+		if got != tt.want {
+			t.Errorf("EvaluateMap(%q, %v) = %v; want %v", tt.query, tt.version, got, tt.want)
+		}
+	}
+
+	r, err := ParseQuery(`app_version ge [semver]"2.10.0"`, nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if _, err := r.EvaluateMap(map[string]any{"app_version": "latest"}); !errors.Is(err, ErrorTypeMismatch) {
+		t.Errorf("EvaluateMap with a malformed version: error = %v; want ErrorTypeMismatch", err)
+	}
+	if _, err := ParseQuery(`app_version ge [semver]"2.10"`, nil); !errors.Is(err, ErrorInvalidSemver) {
+		t.Errorf("ParseQuery with a malformed version: error = %v; want ErrorInvalidSemver", err)
+	}
+}
+
+// mustParseSemver parses s or fails the test.
+func mustParseSemver(t *testing.T, s string) Semver {
+	t.Helper()
+	v, err := ParseSemver(s)
+	if err != nil {
+		t.Fatalf("ParseSemver(%q) error: %v", s, err)
+	}
+	return v
+}
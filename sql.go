@@ -0,0 +1,442 @@
+package rule
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQLDialect describes how a database spells the parts of a WHERE clause that differ between
+// databases. PostgresDialect, MySQLDialect and SQLiteDialect are provided; implement it for others.
+type SQLDialect interface {
+	// Placeholder returns the placeholder of the n-th bind argument, counting from 1, e.g. "$1" or "?".
+	Placeholder(n int) string
+
+	// NamedPlaceholder returns the placeholder of the bind argument called name, e.g. "@p1",
+	// or "" if the database does not support named arguments.
+	NamedPlaceholder(name string) string
+
+	// QuoteIdentifier quotes a column name, e.g. "name" or `name`.
+	QuoteIdentifier(name string) string
+
+	// Match returns the condition that column matches the regular expression given by the bind
+	// argument placeholder (the mr operator), or "" if the database has no such operator.
+	Match(column, placeholder string) string
+}
+
+var (
+	// PostgresDialect writes placeholders as $1, $2, ..., named placeholders as @p1 (as used by pgx.NamedArgs),
+	// quotes identifiers with double quotes and matches regular expressions with ~.
+	PostgresDialect SQLDialect = postgresDialect{}
+
+	// MySQLDialect writes placeholders as ?, quotes identifiers with backticks and matches regular
+	// expressions with REGEXP. It has no named placeholders.
+	MySQLDialect SQLDialect = mysqlDialect{}
+
+	// SQLiteDialect writes placeholders as ?, named placeholders as @p1, quotes identifiers with double
+	// quotes and matches regular expressions with REGEXP, which needs a regexp() function registered
+	// with the driver.
+	SQLiteDialect SQLDialect = sqliteDialect{}
+)
+
+// postgresDialect implements PostgresDialect.
+type postgresDialect struct{}
+
+func (postgresDialect) Placeholder(n int) string            { return "$" + strconv.Itoa(n) }
+func (postgresDialect) NamedPlaceholder(name string) string { return "@" + name }
+func (postgresDialect) QuoteIdentifier(name string) string  { return quoteIdentifier(name, '"') }
+func (postgresDialect) Match(column, placeholder string) string {
+	return column + " ~ " + placeholder
+}
+
+// mysqlDialect implements MySQLDialect.
+type mysqlDialect struct{}
+
+func (mysqlDialect) Placeholder(int) string             { return "?" }
+func (mysqlDialect) NamedPlaceholder(string) string     { return "" }
+func (mysqlDialect) QuoteIdentifier(name string) string { return quoteIdentifier(name, '`') }
+func (mysqlDialect) Match(column, placeholder string) string {
+	return column + " REGEXP " + placeholder
+}
+
+// sqliteDialect implements SQLiteDialect.
+type sqliteDialect struct{}
+
+func (sqliteDialect) Placeholder(int) string              { return "?" }
+func (sqliteDialect) NamedPlaceholder(name string) string { return "@" + name }
+func (sqliteDialect) QuoteIdentifier(name string) string  { return quoteIdentifier(name, '"') }
+func (sqliteDialect) Match(column, placeholder string) string {
+	return column + " REGEXP " + placeholder
+}
+
+// quoteIdentifier encloses name in quote, doubling any quote inside it.
+func quoteIdentifier(name string, quote byte) string {
+	q := string(quote)
+	return q + strings.ReplaceAll(name, q, q+q) + q
+}
+
+// SQLFunction translates a function call to an SQL expression for Rule.ToSQL. args are the parsed
+// argument values in call order; bind adds a bind argument and returns its placeholder, e.g.
+//
+//	func(args []any, bind func(any) string) (string, error) {
+//		return "lower(" + bind(args[0]) + ")", nil
+//	}
+type SQLFunction func(args []any, bind func(value any) string) (string, error)
+
+// SQLOptions controls Rule.ToSQL.
+//
+// Fields:
+//   - Dialect: the database to write the condition for; PostgresDialect if nil
+//   - Column: maps an attribute name (Parameter.Name, e.g. "address.city") to the SQL expression of its
+//     column, which is used as is; return an error to reject an attribute. If nil, each dot-separated
+//     part of the name is quoted with Dialect.QuoteIdentifier, e.g. "address"."city"
+//   - Functions: SQL translations of function calls by function name; other calls fail with ErrorUntranslatable
+//   - NamedArgs: if true, the bind arguments are sql.NamedArg values called p1, p2, ... and the condition
+//     uses Dialect.NamedPlaceholder
+type SQLOptions struct {
+	Dialect   SQLDialect
+	Column    func(name string) (string, error)
+	Functions map[string]SQLFunction
+	NamedArgs bool
+}
+
+// ToSQL translates the Rule into an SQL condition for a WHERE clause (without the WHERE keyword) and
+// its bind arguments, e.g.
+//
+//	where, args, err := ruleSet.ToSQL(&rule.SQLOptions{Dialect: rule.MySQLDialect})
+//	rows, err := db.Query("SELECT id FROM users WHERE "+where, args...)
+//
+// Values are always bound, never written into the condition. A NULL column behaves like a missing
+// attribute: its comparisons are false, and a negation such as "not age gt 30" becomes
+// ("age" > $1) IS NOT TRUE, which includes NULLs like Evaluate does. "pr" becomes IS NOT NULL, and
+// "eq null" / "ne null" become IS NULL / IS NOT NULL, just as Evaluate matches "eq null" for a
+// missing attribute and treats "ne null" like "pr". co, sw and ew become LIKE with the wildcards of
+// the value escaped, and attributes listed in Config.CaseInsensitive are compared with LOWER on both
+// sides. now() and arithmetic on constants are computed here (with Config.Now) and bound as values;
+// arithmetic on columns is translated, with "/" always dividing exactly.
+//
+// Value paths, quantifiers, len, wi, in / nin with a string, time arithmetic on columns, [semver] and
+// [dur] values and function calls without an SQLOptions.Functions entry fail with ErrorUntranslatable.
+func (g *Rule) ToSQL(opts *SQLOptions) (string, []any, error) {
+	b := &sqlBuilder{
+		dialect: PostgresDialect,
+		ec:      &evalContext{ctx: context.Background(), clock: g.clock},
+	}
+	if opts != nil {
+		if opts.Dialect != nil {
+			b.dialect = opts.Dialect
+		}
+		b.columnName = opts.Column
+		b.functions = opts.Functions
+		b.named = opts.NamedArgs
+	}
+	if b.ec.clock == nil {
+		b.ec.clock = time.Now
+	}
+	if b.columnName == nil {
+		b.columnName = b.quoteName
+	}
+	if b.named && b.dialect.NamedPlaceholder("p1") == "" {
+		return "", nil, newErrorUntranslatable("SQL", "the dialect does not support named arguments")
+	}
+
+	cond, err := b.condition(&g.exprTree)
+	if err != nil {
+		return "", nil, err
+	}
+	return cond, b.args, nil
+}
+
+// sqlComparisons maps the comparison operators to SQL.
+var sqlComparisons = map[string]string{
+	"eq": "=",
+	"ne": "<>",
+	"gt": ">",
+	"lt": "<",
+	"ge": ">=",
+	"le": "<=",
+}
+
+// sqlBuilder collects the bind arguments while Rule.ToSQL translates the exprTree.
+//
+// Fields:
+//   - dialect, columnName, functions, named: from SQLOptions
+//   - ec: computes now() and arithmetic on constants, reading the clock at most once
+//   - args: the bind arguments so far
+type sqlBuilder struct {
+	dialect    SQLDialect
+	columnName func(name string) (string, error)
+	functions  map[string]SQLFunction
+	named      bool
+	ec         *evalContext
+	args       []any
+}
+
+// bind adds a bind argument and returns its placeholder.
+func (b *sqlBuilder) bind(value any) string {
+	n := len(b.args) + 1
+	if b.named {
+		name := "p" + strconv.Itoa(n)
+		b.args = append(b.args, sql.Named(name, value))
+		return b.dialect.NamedPlaceholder(name)
+	}
+	b.args = append(b.args, value)
+	return b.dialect.Placeholder(n)
+}
+
+// quoteName is the default SQLOptions.Column: it quotes each dot-separated part of name.
+func (b *sqlBuilder) quoteName(name string) (string, error) {
+	parts := strings.Split(name, ".")
+	for i := range parts {
+		parts[i] = b.dialect.QuoteIdentifier(parts[i])
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// condition translates an exprTree node. An "and" inside an "or" (or the other way round) is always
+// parenthesized, and a negated node becomes (...) IS NOT TRUE, so that NULL counts as false.
+func (b *sqlBuilder) condition(e *exprTree) (string, error) {
+	if e.op == "" {
+		if e.param == nil {
+			return "", ErrorNoExpression
+		}
+		return b.comparison(e.param, e.not)
+	}
+
+	left, err := b.condition(e.left)
+	if err != nil {
+		return "", err
+	}
+	right, err := b.condition(e.right)
+	if err != nil {
+		return "", err
+	}
+	if e.left.op != "" && e.left.op != e.op && !e.left.not {
+		left = "(" + left + ")"
+	}
+	if e.right.op != "" && e.right.op != e.op && !e.right.not {
+		right = "(" + right + ")"
+	}
+	cond := left + " " + strings.ToUpper(e.op) + " " + right
+	if e.not {
+		return "(" + cond + ") IS NOT TRUE", nil
+	}
+	return cond, nil
+}
+
+// comparison translates the leaf comparison of p, negated if not is set.
+func (b *sqlBuilder) comparison(p *Parameter, not bool) (string, error) {
+	switch {
+	case p.Filter != nil:
+		return "", newErrorUntranslatable("SQL", fmt.Sprintf("the value path %s[...] has no SQL equivalent", p.Name))
+	case p.quantifier != "":
+		return "", newErrorUntranslatable("SQL", fmt.Sprintf("the quantifier %s(%s) has no SQL equivalent", p.quantifier, p.Name))
+	}
+
+	column, err := b.left(p)
+	if err != nil {
+		return "", err
+	}
+	if p.operator == "pr" || p.compareValue == nil && (p.operator == "eq" || p.operator == "ne") {
+		if (p.operator == "eq") != not {
+			return column + " IS NULL", nil
+		}
+		return column + " IS NOT NULL", nil
+	}
+
+	cond, err := b.compare(p, column)
+	if err != nil {
+		return "", err
+	}
+	if not {
+		return "(" + cond + ") IS NOT TRUE", nil
+	}
+	return cond, nil
+}
+
+// compare translates the comparison of column (the left-hand side of p) with p.compareValue.
+func (b *sqlBuilder) compare(p *Parameter, column string) (string, error) {
+	lower := func(s string) string {
+		if p.caseInsensitive && foldsCase(p.operator) {
+			return "LOWER(" + s + ")"
+		}
+		return s
+	}
+
+	switch p.operator {
+	case "eq", "ne", "gt", "lt", "ge", "le":
+		if _, ok := p.compareValue.(netip.Addr); ok && p.operator != "eq" && p.operator != "ne" {
+			return "", newErrorUntranslatable("SQL", "IP addresses can only be compared with eq and ne")
+		}
+		right, err := b.operand(p.compareValue)
+		if err != nil {
+			return "", err
+		}
+		return lower(column) + " " + sqlComparisons[p.operator] + " " + lower(right), nil
+
+	case "co", "sw", "ew":
+		s, ok := p.compareValue.(string)
+		if !ok {
+			return "", newErrorUntranslatable("SQL", fmt.Sprintf("%s needs a string value", p.operator))
+		}
+		pattern := escapeLike(s)
+		switch p.operator {
+		case "co":
+			pattern = "%" + pattern + "%"
+		case "sw":
+			pattern += "%"
+		case "ew":
+			pattern = "%" + pattern
+		}
+		return lower(column) + " LIKE " + lower(b.bind(pattern)) + " ESCAPE '!'", nil
+
+	case "in", "nin":
+		list := reflect.ValueOf(p.compareValue)
+		if list.Kind() != reflect.Slice {
+			return "", newErrorUntranslatable("SQL", fmt.Sprintf("%s with a string checks for a substring", p.operator))
+		}
+		placeholders := make([]string, list.Len())
+		for i := range placeholders {
+			value, err := sqlValue(list.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			placeholders[i] = lower(b.bind(value))
+		}
+		op := " IN ("
+		if p.operator == "nin" {
+			op = " NOT IN ("
+		}
+		return lower(column) + op + strings.Join(placeholders, ", ") + ")", nil
+
+	case "mr":
+		re, ok := p.compareValue.(*regexp.Regexp)
+		if !ok {
+			return "", newErrorUntranslatable("SQL", "mr needs a pattern")
+		}
+		cond := b.dialect.Match(column, b.bind(re.String()))
+		if cond == "" {
+			return "", newErrorUntranslatable("SQL", "the dialect does not support regular expressions")
+		}
+		return cond, nil
+	}
+	return "", newErrorUntranslatable("SQL", fmt.Sprintf("the operator %s has no SQL equivalent", p.operator))
+}
+
+// left translates the left-hand side of p: its column, function call or computed expression.
+func (b *sqlBuilder) left(p *Parameter) (string, error) {
+	if p.leftExpr != nil {
+		return b.operand(p.leftExpr)
+	}
+	return b.column(p)
+}
+
+// column translates an attribute with SQLOptions.Column, or a function call with SQLOptions.Functions.
+func (b *sqlBuilder) column(p *Parameter) (string, error) {
+	if p.InputType != FunctionCall {
+		return b.columnName(p.Name)
+	}
+	fn, ok := b.functions[p.Name]
+	if !ok {
+		return "", newErrorUntranslatable("SQL", fmt.Sprintf("the function %s has no SQL mapping", p.Name))
+	}
+	args := make([]any, len(p.FunctionArguments))
+	for i, arg := range p.FunctionArguments {
+		args[i] = arg.Value
+	}
+	return fn(args, b.bind)
+}
+
+// operand translates a comparison value or an operand of arithmetic: constants (including now()
+// and arithmetic on constants) are bound, attributes become columns and arithmetic on attributes
+// becomes an SQL expression.
+func (b *sqlBuilder) operand(v any) (string, error) {
+	switch x := v.(type) {
+	case paramRef:
+		return b.column(x.param)
+	case lengthValue:
+		return "", newErrorUntranslatable("SQL", fmt.Sprintf("len(%s) has no SQL equivalent", x.param.Name))
+	case arithmeticValue:
+		if hasRefs(x) {
+			return b.arithmetic(x)
+		}
+	}
+
+	if expr, ok := v.(valueExpr); ok {
+		var err error
+		if v, _, err = expr.eval(b.ec); err != nil {
+			return "", err
+		}
+	}
+	value, err := sqlValue(v)
+	if err != nil {
+		return "", err
+	}
+	return b.bind(value), nil
+}
+
+// arithmetic translates arithmetic involving attributes. Like computeArithmetic, "/" divides exactly,
+// so its left operand is multiplied by 1.0 for integer columns.
+func (b *sqlBuilder) arithmetic(a arithmeticValue) (string, error) {
+	operands := [2]string{}
+	for i, v := range []any{a.left, a.right} {
+		if !hasRefs(v) {
+			value, _, err := evalValue(v, b.ec)
+			if err != nil {
+				return "", err
+			}
+			if _, ok := toNumber(value); !ok {
+				return "", newErrorUntranslatable("SQL", fmt.Sprintf("arithmetic with %s values has no SQL equivalent", typeName(value)))
+			}
+		}
+		operand, err := b.operand(v)
+		if err != nil {
+			return "", err
+		}
+		operands[i] = operand
+	}
+	if a.op == "/" {
+		return "(" + operands[0] + " * 1.0 / " + operands[1] + ")", nil
+	}
+	return "(" + operands[0] + " " + a.op + " " + operands[1] + ")", nil
+}
+
+// hasRefs reports whether v refers to attributes or function calls, and so cannot be computed
+// without the document.
+func hasRefs(v any) bool {
+	switch x := v.(type) {
+	case paramRef, lengthValue:
+		return true
+	case arithmeticValue:
+		return hasRefs(x.left) || hasRefs(x.right)
+	}
+	return false
+}
+
+// sqlValue converts a comparison value to a bind argument: IP addresses and prefixes become strings,
+// while [semver] and [dur] values are rejected, since databases cannot compare them as such.
+func sqlValue(v any) (any, error) {
+	switch x := v.(type) {
+	case netip.Addr:
+		return x.String(), nil
+	case netip.Prefix:
+		return x.String(), nil
+	case Semver, time.Duration:
+		return nil, newErrorUntranslatable("SQL", fmt.Sprintf("%s values have no SQL equivalent", typeName(v)))
+	}
+	return v, nil
+}
+
+// escapeLike escapes the LIKE wildcards % and _ in s, and the escape character ! itself.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// likeEscaper prefixes the LIKE wildcards and the escape character with the escape character !.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
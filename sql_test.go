@@ -0,0 +1,248 @@
+package rule
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToSQL(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	config := &Config{Now: func() time.Time { return now }, CaseInsensitive: []string{"email"}}
+
+	tests := []struct {
+		query    string
+		wantSQL  string
+		wantArgs []any
+	}{
+		{`age gt 30`, `"age" > $1`, []any{int64(30)}},
+		{`age ge 18 and age lt 65`, `"age" >= $1 AND "age" < $2`, []any{int64(18), int64(65)}},
+		{`a eq 1 or b eq 2 and c eq 3`, `"a" = $1 OR ("b" = $2 AND "c" = $3)`, []any{int64(1), int64(2), int64(3)}},
+		{`(a eq 1 or b eq 2) and c eq 3`, `("a" = $1 OR "b" = $2) AND "c" = $3`, []any{int64(1), int64(2), int64(3)}},
+		{`status ne "active"`, `"status" <> $1`, []any{"active"}},
+		{`address.city eq "London"`, `"address"."city" = $1`, []any{"London"}},
+		{`price eq [d]"9.99"`, `"price" = $1`, []any{decimal.RequireFromString("9.99")}},
+		{`active eq true`, `"active" = $1`, []any{true}},
+
+		// NULL handling
+		{`email pr`, `"email" IS NOT NULL`, nil},
+		{`not email pr`, `"email" IS NULL`, nil},
+		{`deleted_at eq null`, `"deleted_at" IS NULL`, nil},
+		{`deleted_at ne null`, `"deleted_at" IS NOT NULL`, nil},
+		{`not deleted_at eq null`, `"deleted_at" IS NOT NULL`, nil},
+		{`not age gt 30`, `("age" > $1) IS NOT TRUE`, []any{int64(30)}},
+		{`not (a eq 1 or b eq 2)`, `("a" = $1 OR "b" = $2) IS NOT TRUE`, []any{int64(1), int64(2)}},
+		{`c eq 3 and not (a eq 1 or b eq 2)`, `"c" = $1 AND ("a" = $2 OR "b" = $3) IS NOT TRUE`, []any{int64(3), int64(1), int64(2)}},
+
+		// LIKE escaping
+		{`name co "50%_off"`, `"name" LIKE $1 ESCAPE '!'`, []any{"%50!%!_off%"}},
+		{`name sw "a!b"`, `"name" LIKE $1 ESCAPE '!'`, []any{"a!!b%"}},
+		{`name ew ".com"`, `"name" LIKE $1 ESCAPE '!'`, []any{"%.com"}},
+		{`email ew "@EXAMPLE.com"`, `LOWER("email") LIKE LOWER($1) ESCAPE '!'`, []any{"%@EXAMPLE.com"}},
+		{`email eq "Ada@Example.com"`, `LOWER("email") = LOWER($1)`, []any{"Ada@Example.com"}},
+
+		// Lists and patterns
+		{`lang in ["en", "fr"]`, `"lang" IN ($1, $2)`, []any{"en", "fr"}},
+		{`id nin [1, 2, 3]`, `"id" NOT IN ($1, $2, $3)`, []any{int64(1), int64(2), int64(3)}},
+		{`email in ["a@x.org"]`, `LOWER("email") IN (LOWER($1))`, []any{"a@x.org"}},
+		{`sku mr "^[A-Z]{3}-\\d+$"`, `"sku" ~ $1`, []any{`^[A-Z]{3}-\d+$`}},
+		{`client_ip eq [ip]"10.0.0.1"`, `"client_ip" = $1`, []any{"10.0.0.1"}},
+
+		// Attributes, arithmetic and now()
+		{`shipped_at gt ordered_at`, `"shipped_at" > "ordered_at"`, nil},
+		{`price * quantity gt 1000`, `("price" * "quantity") > $1`, []any{int64(1000)}},
+		{`score / max_score ge 0.8`, `("score" * 1.0 / "max_score") >= $1`, []any{0.8}},
+		{`(a + b) * 2 eq c - 1`, `(("a" + "b") * $1) = ("c" - $2)`, []any{int64(2), int64(1)}},
+		{`last_login gt now() - 30d`, `"last_login" > $1`, []any{now.Add(-30 * 24 * time.Hour)}},
+		{`x eq 2 * 3`, `"x" = $1`, []any{int64(6)}},
+	}
+	for _, tt := range tests {
+		r, err := ParseQuery(tt.query, config)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+		}
+		gotSQL, gotArgs, err := r.ToSQL(nil)
+		if err != nil {
+			t.Errorf("ToSQL(%q) error: %v", tt.query, err)
+			continue
+		}
+		if gotSQL != tt.wantSQL {
+			t.Errorf("ToSQL(%q) = %s; want %s", tt.query, gotSQL, tt.wantSQL)
+		}
+		if !sqlArgsEqual(gotArgs, tt.wantArgs) {
+			t.Errorf("ToSQL(%q) args = %#v; want %#v", tt.query, gotArgs, tt.wantArgs)
+		}
+	}
+}
+
+func TestToSQLLeftToRight(t *testing.T) {
+	r, err := ParseQuery(`a eq 1 or b eq 2 and c eq 3`, &Config{LeftToRightLogic: true})
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	got, _, err := r.ToSQL(nil)
+	if err != nil {
+		t.Fatalf("ToSQL error: %v", err)
+	}
+	if want := `("a" = $1 OR "b" = $2) AND "c" = $3`; got != want {
+		t.Errorf("ToSQL = %s; want %s", got, want)
+	}
+}
+
+func TestToSQLDialects(t *testing.T) {
+	r, err := ParseQuery(`name sw "A" and (role in ["admin", "dev"] or sku mr "^x")`, nil)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	tests := []struct {
+		opts     *SQLOptions
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			&SQLOptions{Dialect: PostgresDialect},
+			`"name" LIKE $1 ESCAPE '!' AND ("role" IN ($2, $3) OR "sku" ~ $4)`,
+			[]any{"A%", "admin", "dev", "^x"},
+		},
+		{
+			&SQLOptions{Dialect: MySQLDialect},
+			"`name` LIKE ? ESCAPE '!' AND (`role` IN (?, ?) OR `sku` REGEXP ?)",
+			[]any{"A%", "admin", "dev", "^x"},
+		},
+		{
+			&SQLOptions{Dialect: SQLiteDialect},
+			`"name" LIKE ? ESCAPE '!' AND ("role" IN (?, ?) OR "sku" REGEXP ?)`,
+			[]any{"A%", "admin", "dev", "^x"},
+		},
+		{
+			&SQLOptions{Dialect: SQLiteDialect, NamedArgs: true},
+			`"name" LIKE @p1 ESCAPE '!' AND ("role" IN (@p2, @p3) OR "sku" REGEXP @p4)`,
+			[]any{sql.Named("p1", "A%"), sql.Named("p2", "admin"), sql.Named("p3", "dev"), sql.Named("p4", "^x")},
+		},
+		{
+			&SQLOptions{Column: func(name string) (string, error) { return "u." + name, nil }},
+			`u.name LIKE $1 ESCAPE '!' AND (u.role IN ($2, $3) OR u.sku ~ $4)`,
+			[]any{"A%", "admin", "dev", "^x"},
+		},
+	}
+	for _, tt := range tests {
+		gotSQL, gotArgs, err := r.ToSQL(tt.opts)
+		if err != nil {
+			t.Errorf("ToSQL(%+v) error: %v", tt.opts, err)
+			continue
+		}
+		if gotSQL != tt.wantSQL {
+			t.Errorf("ToSQL(%+v) = %s; want %s", tt.opts, gotSQL, tt.wantSQL)
+		}
+		if !sqlArgsEqual(gotArgs, tt.wantArgs) {
+			t.Errorf("ToSQL(%+v) args = %#v; want %#v", tt.opts, gotArgs, tt.wantArgs)
+		}
+	}
+
+	if _, _, err := r.ToSQL(&SQLOptions{Dialect: MySQLDialect, NamedArgs: true}); !errors.Is(err, ErrorUntranslatable) {
+		t.Errorf("ToSQL with named arguments for MySQL: error = %v; want ErrorUntranslatable", err)
+	}
+
+	if got := MySQLDialect.QuoteIdentifier("we`ird"); got != "`we``ird`" {
+		t.Errorf("QuoteIdentifier = %s; want `we``ird`", got)
+	}
+	if got := PostgresDialect.QuoteIdentifier(`we"ird`); got != `"we""ird"` {
+		t.Errorf(`QuoteIdentifier = %s; want "we""ird"`, got)
+	}
+}
+
+func TestToSQLFunctionsAndColumns(t *testing.T) {
+	functions := NewFunctionRegistry()
+	if err := functions.Register("get_score", func(args ...any) (any, error) { return 0, nil }, ArgTypeString); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	r, err := ParseQuery(`get_score("math") gt 90 and name eq "Ada"`, &Config{Functions: functions})
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	// Function calls need an SQL mapping
+	if _, _, err := r.ToSQL(nil); !errors.Is(err, ErrorUntranslatable) || !strings.Contains(err.Error(), "get_score") {
+		t.Errorf("ToSQL without a mapping: error = %v; want ErrorUntranslatable for get_score", err)
+	}
+
+	gotSQL, gotArgs, err := r.ToSQL(&SQLOptions{
+		Functions: map[string]SQLFunction{
+			"get_score": func(args []any, bind func(any) string) (string, error) {
+				return "(SELECT score FROM scores WHERE subject = " + bind(args[0]) + ")", nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ToSQL error: %v", err)
+	}
+	if want := `(SELECT score FROM scores WHERE subject = $1) > $2 AND "name" = $3`; gotSQL != want {
+		t.Errorf("ToSQL = %s; want %s", gotSQL, want)
+	}
+	if want := []any{"math", int64(90), "Ada"}; !sqlArgsEqual(gotArgs, want) {
+		t.Errorf("ToSQL args = %#v; want %#v", gotArgs, want)
+	}
+
+	// The column mapper may reject attributes
+	errUnknown := errors.New("unknown column")
+	_, _, err = r.ToSQL(&SQLOptions{
+		Functions: map[string]SQLFunction{"get_score": func([]any, func(any) string) (string, error) { return "1", nil }},
+		Column: func(name string) (string, error) {
+			return "", fmt.Errorf("%w: %s", errUnknown, name)
+		},
+	})
+	if !errors.Is(err, errUnknown) {
+		t.Errorf("ToSQL with a rejecting column mapper: error = %v; want %v", err, errUnknown)
+	}
+
+	untranslatable := []string{
+		`emails[type eq "work"]`,
+		`any(tags) eq "vip"`,
+		`len(tags) gt 3`,
+		`client_ip wi "10.0.0.0/8"`,
+		`name in "abc"`,
+		`ts gt created + 2d`,
+		`version gt [semver]"1.2.3"`,
+		`timeout gt [dur]"1m"`,
+		`client_ip gt [ip]"10.0.0.1"`,
+	}
+	for _, query := range untranslatable {
+		r, err := ParseQuery(query, nil)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error: %v", query, err)
+		}
+		if _, _, err := r.ToSQL(nil); !errors.Is(err, ErrorUntranslatable) {
+			t.Errorf("ToSQL(%q) error = %v; want ErrorUntranslatable", query, err)
+		}
+	}
+
+	var empty Rule
+	if _, _, err := empty.ToSQL(nil); !errors.Is(err, ErrorNoExpression) {
+		t.Errorf("ToSQL of an empty Rule: error = %v; want ErrorNoExpression", err)
+	}
+}
+
+// sqlArgsEqual compares bind arguments, using Equal for decimals.
+func sqlArgsEqual(got, want []any) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if d, ok := want[i].(decimal.Decimal); ok {
+			if g, ok := got[i].(decimal.Decimal); !ok || !g.Equal(d) {
+				return false
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got[i], want[i]) {
+			return false
+		}
+	}
+	return true
+}
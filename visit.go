@@ -1,8 +1,12 @@
 package rule
 
 import (
+	"fmt"
 	"github.com/antlr4-go/antlr/v4"
 	parser "github.com/sky93/go-rule/internal/antlr4"
+	"net/netip"
+	"reflect"
+	"regexp"
 	"strings"
 )
 
@@ -11,7 +15,11 @@ import (
 // See parse.go for queryVisitor's parseValue / parseTypedValue and other helper methods.
 type queryVisitor struct {
 	antlr.ParseTreeVisitor
-	parameters []Parameter
+	parameters       []Parameter
+	maxPatternLength int
+	caseInsensitive  map[string]bool
+	leftToRight      bool
+	pendingRefs      []*Parameter
 	parser.BaseSCIMQueryVisitor
 }
 
@@ -31,6 +39,8 @@ func (v *queryVisitor) visit(ctx parser.IQueryContext) (any, error) {
 		return v.visitPresentExp(actual)
 	case *parser.CompareExpContext:
 		return v.visitCompareExp(actual)
+	case *parser.ValuePathExpContext:
+		return v.visitValuePathExp(actual)
 	default:
 		return nil, ErrorInvalidExpression
 	}
@@ -50,21 +60,77 @@ func (v *queryVisitor) visitParenExp(ctx *parser.ParenExpContext) (*exprTree, er
 	return subExp, nil
 }
 
-// visitPresentExp handles a "pr" operator, e.g. "attribute pr" meaning "attribute is present".
+// visitPresentExp handles a "pr" operator, e.g. "attribute pr" meaning "attribute is present",
+// or "manager() pr" for the result of a function call. A NOT token may prefix the attribute.
 func (v *queryVisitor) visitPresentExp(ctx *parser.PresentExpContext) (*exprTree, error) {
+	p := Parameter{
+		id:        len(v.parameters),
+		InputType: Expression,
+		operator:  "pr",
+	}
+	if call := ctx.AttrPath().FunctionCall(); call != nil {
+		if isNowCall(ctx.AttrPath()) {
+			start := ctx.AttrPath().GetStart()
+			return nil, newSyntaxError(fmt.Sprintf("%d:%d: pr must refer to an attribute or a function call", start.GetLine(), start.GetColumn()))
+		}
+		name, args, err := v.parseFunctionCall(call)
+		if err != nil {
+			return nil, err
+		}
+		p.Name, p.InputType, p.FunctionArguments = name, FunctionCall, args
+	} else {
+		p.Name = v.getAttrName(ctx.AttrPath())
+	}
+	v.parameters = append(v.parameters, p)
+	return &exprTree{not: ctx.NOT() != nil, param: &p}, nil
+}
+
+// visitValuePathExp handles a SCIM value path, e.g. emails[type eq "work" and value ew "@example.com"].
+// The bracketed filter is visited on its own, so that its Parameters are named relative to an element
+// of the multi-valued attribute; attributes listed in Config.CaseInsensitive as e.g. "emails.value"
+// apply to them. A NOT token may prefix the value path.
+func (v *queryVisitor) visitValuePathExp(ctx *parser.ValuePathExpContext) (*exprTree, error) {
+	if ctx.AttrPath().FunctionCall() != nil {
+		start := ctx.GetStart()
+		return nil, newSyntaxError(fmt.Sprintf("%d:%d: a value path must refer to an attribute", start.GetLine(), start.GetColumn()))
+	}
 	name := v.getAttrName(ctx.AttrPath())
+
+	sub := &queryVisitor{
+		maxPatternLength: v.maxPatternLength,
+		caseInsensitive:  make(map[string]bool),
+		leftToRight:      v.leftToRight,
+	}
+	for attr := range v.caseInsensitive {
+		if rest, ok := strings.CutPrefix(attr, name+"."); ok {
+			sub.caseInsensitive[rest] = true
+		}
+	}
+	filterAny, err := sub.visit(ctx.Query())
+	if err != nil {
+		return nil, err
+	}
+
 	p := Parameter{
 		id:        len(v.parameters),
 		Name:      name,
 		InputType: Expression,
-		operator:  "pr",
+		Filter: &ValueFilter{
+			Params:   sub.parameters,
+			exprTree: filterAny.(*exprTree),
+		},
 	}
 	v.parameters = append(v.parameters, p)
-	return &exprTree{param: &p}, nil
+	return &exprTree{not: ctx.NOT() != nil, param: &p}, nil
 }
 
 // visitLogicalExp handles expressions joined by "and" / "or", e.g. "query and query".
+// The parser gives "and" a higher precedence than "or"; with leftToRight set, an unparenthesized
+// chain is instead regrouped strictly from left to right.
 func (v *queryVisitor) visitLogicalExp(ctx *parser.LogicalExpContext) (*exprTree, error) {
+	if v.leftToRight {
+		return v.visitLogicalChain(ctx)
+	}
 	leftAny, err := v.visit(ctx.Query(0))
 	if err != nil {
 		return nil, err
@@ -76,7 +142,7 @@ func (v *queryVisitor) visitLogicalExp(ctx *parser.LogicalExpContext) (*exprTree
 	leftNode := leftAny.(*exprTree)
 	rightNode := rightAny.(*exprTree)
 
-	op := strings.ToLower(ctx.LOGICAL_OPERATOR().GetText())
+	op := strings.ToLower(ctx.GetOp().GetText())
 	return &exprTree{
 		op:    op,
 		left:  leftNode,
@@ -84,28 +150,120 @@ func (v *queryVisitor) visitLogicalExp(ctx *parser.LogicalExpContext) (*exprTree
 	}, nil
 }
 
-// visitCompareExp handles a single comparison: (attrPath|functionCall) operator value
+// visitLogicalChain visits the operands of an unparenthesized and/or chain in source order and
+// folds them from left to right, e.g. "a or b and c" becomes "(a or b) and c".
+func (v *queryVisitor) visitLogicalChain(ctx *parser.LogicalExpContext) (*exprTree, error) {
+	var operands []parser.IQueryContext
+	var ops []string
+	var flatten func(q parser.IQueryContext)
+	flatten = func(q parser.IQueryContext) {
+		logical, ok := q.(*parser.LogicalExpContext)
+		if !ok {
+			operands = append(operands, q)
+			return
+		}
+		flatten(logical.Query(0))
+		ops = append(ops, strings.ToLower(logical.GetOp().GetText()))
+		flatten(logical.Query(1))
+	}
+	flatten(ctx)
+
+	var tree *exprTree
+	for i, operand := range operands {
+		nodeAny, err := v.visit(operand)
+		if err != nil {
+			return nil, err
+		}
+		node := nodeAny.(*exprTree)
+		if i == 0 {
+			tree = node
+			continue
+		}
+		tree = &exprTree{
+			op:    ops[i-1],
+			left:  tree,
+			right: node,
+		}
+	}
+	return tree, nil
+}
+
+// visitCompareExp handles a single comparison: value operator value. A NOT token may prefix the comparison.
+// The left-hand side is usually an attribute or function call, which becomes the Parameter of the leaf,
+// optionally wrapped in a quantifier such as any(tags).
+// Any other left-hand side, such as "price * quantity", is kept as a valueExpr in Parameter.leftExpr.
+// Either side may refer to further attributes or function calls (see parseAttrValue).
 func (v *queryVisitor) visitCompareExp(ctx *parser.CompareExpContext) (*exprTree, error) {
-	isFunc := ctx.AttrPath().FunctionCall() != nil
 	var name string
 	var err error
 	var funcArgs []FunctionArgument
+	var leftExpr valueExpr
+	isFunc := false
 
-	if isFunc {
-		name, funcArgs, err = v.parseFunctionCall(ctx.AttrPath().FunctionCall())
+	var quantifier string
+	var attrCtx parser.IAttrPathContext
+
+	leftCtx := ctx.Value(0)
+	for paren, ok := leftCtx.(*parser.ParenValContext); ok; paren, ok = leftCtx.(*parser.ParenValContext) {
+		leftCtx = paren.Value()
+	}
+	switch left := leftCtx.(type) {
+	case *parser.QuantifierValContext:
+		quantifier = left.GetOp().GetText()
+		attrCtx = left.AttrPath()
+	case *parser.AttrValContext:
+		if !isNowCall(left.AttrPath()) {
+			attrCtx = left.AttrPath()
+		}
+	}
+	if attrCtx != nil {
+		if call := attrCtx.FunctionCall(); call != nil {
+			isFunc = true
+			name, funcArgs, err = v.parseFunctionCall(call)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			name = v.getAttrName(attrCtx)
+		}
+	} else {
+		left, _, _, err := v.parseValue(leftCtx)
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		name = v.getAttrName(ctx.AttrPath())
+		expr, ok := left.(valueExpr)
+		if !ok {
+			start := leftCtx.GetStart()
+			return nil, newSyntaxError(fmt.Sprintf("%d:%d: the left-hand side must refer to an attribute, a function call or now()", start.GetLine(), start.GetColumn()))
+		}
+		leftExpr = expr
+		name = sourceText(leftCtx)
 	}
 
 	opText := strings.ToLower(ctx.GetOp().GetText())
-	valCtx := ctx.Value()
+	valCtx := ctx.Value(1)
 	val, valType, strict, err := v.parseValue(valCtx)
 	if err != nil {
 		return nil, err
 	}
+	if opText == "mr" || opText == "match" {
+		opText = "mr"
+		val, err = v.compilePattern(valCtx, val)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if opText == "wi" || opText == "within" {
+		opText = "wi"
+		val, err = v.parseNetworks(valCtx, val)
+		if err != nil {
+			return nil, err
+		}
+		valType = ArgTypeCIDR
+		if _, ok := val.([]netip.Prefix); ok {
+			valType = ArgTypeList
+		}
+	}
 
 	p := Parameter{
 		id:              len(v.parameters),
@@ -115,12 +273,100 @@ func (v *queryVisitor) visitCompareExp(ctx *parser.CompareExpContext) (*exprTree
 		InputType:       Expression,
 		Expression:      valType,
 		strictTypeCheck: strict,
+		caseInsensitive: v.caseInsensitive[name],
+		leftExpr:        leftExpr,
+		quantifier:      quantifier,
 	}
 	if isFunc {
 		p.InputType = FunctionCall
 		p.FunctionArguments = funcArgs
 	}
 
-	v.parameters = append(v.parameters, p)
-	return &exprTree{param: &p}, nil
+	// A computed left-hand side is not a parameter itself; the attributes it refers to are
+	if leftExpr == nil {
+		v.parameters = append(v.parameters, p)
+	}
+
+	// Attributes and function calls referred to by either side follow the left-hand side
+	for _, ref := range v.pendingRefs {
+		ref.id = len(v.parameters)
+		v.parameters = append(v.parameters, *ref)
+	}
+	v.pendingRefs = nil
+
+	return &exprTree{not: ctx.NOT() != nil, param: &p}, nil
+}
+
+// isNowCall reports whether ctx is the built-in now() call.
+func isNowCall(ctx parser.IAttrPathContext) bool {
+	call := ctx.FunctionCall()
+	return call != nil && call.ATTRNAME().GetText() == "now" && call.ArgList() == nil
+}
+
+// sourceText returns the input text of ctx as written, including whitespace, e.g. "price * quantity".
+func sourceText(ctx antlr.ParserRuleContext) string {
+	start, stop := ctx.GetStart(), ctx.GetStop()
+	return start.GetInputStream().GetTextFromInterval(antlr.NewInterval(start.GetStart(), stop.GetStop()))
+}
+
+// compilePattern compiles the pattern of a regular expression match (mr), so that invalid patterns
+// are reported by ParseQuery with their position and evaluation reuses the *regexp.Regexp.
+func (v *queryVisitor) compilePattern(valCtx parser.IValueContext, val any) (*regexp.Regexp, error) {
+	start := valCtx.GetStart()
+	pattern, ok := val.(string)
+	if !ok {
+		return nil, newErrorInvalidPattern(start.GetLine(), start.GetColumn(), "the pattern must be a string")
+	}
+	if v.maxPatternLength > 0 && len(pattern) > v.maxPatternLength {
+		return nil, newErrorInvalidPattern(start.GetLine(), start.GetColumn(), fmt.Sprintf("the pattern is longer than %d characters", v.maxPatternLength))
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, newErrorInvalidPattern(start.GetLine(), start.GetColumn(), err.Error())
+	}
+	return re, nil
+}
+
+// parseNetworks converts the value of a "within network" comparison (wi) to a netip.Prefix, or to a
+// []netip.Prefix for a list. Prefixes may be given as [cidr] values or as strings; a single address
+// such as [ip]"10.0.0.1" matches only itself. Invalid prefixes fail with ErrorInvalidNetwork.
+func (v *queryVisitor) parseNetworks(valCtx parser.IValueContext, val any) (any, error) {
+	start := valCtx.GetStart()
+	toPrefix := func(elem any) (netip.Prefix, error) {
+		switch n := elem.(type) {
+		case netip.Prefix:
+			return n, nil
+		case netip.Addr:
+			return netip.PrefixFrom(n, n.BitLen()), nil
+		case string:
+			prefix, err := netip.ParsePrefix(n)
+			if err != nil {
+				return netip.Prefix{}, newErrorInvalidNetwork(start.GetLine(), start.GetColumn(), err.Error())
+			}
+			return prefix.Masked(), nil
+		}
+		return netip.Prefix{}, newErrorInvalidNetwork(start.GetLine(), start.GetColumn(), fmt.Sprintf("%v is not a CIDR prefix", elem))
+	}
+
+	switch list := val.(type) {
+	case []string:
+		prefixes := make([]netip.Prefix, len(list))
+		for i, elem := range list {
+			prefix, err := toPrefix(elem)
+			if err != nil {
+				return nil, err
+			}
+			prefixes[i] = prefix
+		}
+		return prefixes, nil
+	case []netip.Prefix, []netip.Addr:
+		rv := reflect.ValueOf(list)
+		prefixes := make([]netip.Prefix, rv.Len())
+		for i := range prefixes {
+			prefix, _ := toPrefix(rv.Index(i).Interface())
+			prefixes[i] = prefix
+		}
+		return prefixes, nil
+	}
+	return toPrefix(val)
 }